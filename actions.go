@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// recentRunsPerWorkflow is how many runs of each workflow the detail view
+// lists per branch, newest first.
+const recentRunsPerWorkflow = 3
+
+// workflowFetchConcurrency bounds how many workflows' runs are requested at once.
+const workflowFetchConcurrency = 4
+
+// WorkflowRun is a recent GitHub Actions run of one workflow on one branch.
+type WorkflowRun struct {
+	ID         int64
+	WorkflowID int64
+	Workflow   string
+	Branch     string
+	Event      string
+	Status     string // queued, in_progress, completed, ...
+	Conclusion string // success, failure, cancelled, ... once completed
+	URL        string
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (r WorkflowRun) Duration() time.Duration {
+	if r.StartedAt.IsZero() {
+		return 0
+	}
+	end := r.UpdatedAt
+	if r.Status != "completed" {
+		end = time.Now()
+	}
+	return end.Sub(r.StartedAt).Round(time.Second)
+}
+
+func (r WorkflowRun) Failed() bool {
+	return r.Status == "completed" && (r.Conclusion == "failure" || r.Conclusion == "timed_out")
+}
+
+type workflowRunsLoadedMsg struct {
+	repoURL string
+	runs    []WorkflowRun
+	err     error
+}
+
+type workflowRerunMsg struct {
+	repoURL string
+	err     error
+}
+
+func loadWorkflowRunsCmd(gh *ghSession, repo GitRepo) tea.Cmd {
+	return func() tea.Msg {
+		runs, err := fetchWorkflowRuns(gh, repo)
+		return workflowRunsLoadedMsg{repoURL: repo.GitHubURL, runs: runs, err: err}
+	}
+}
+
+func rerunFailedJobsCmd(gh *ghSession, repoURL string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		nameWithOwner, ok := repoNameWithOwner(repoURL)
+		if !ok {
+			return workflowRerunMsg{repoURL: repoURL, err: fmt.Errorf("invalid GitHub URL format")}
+		}
+		_, err := gh.api("-X", "POST", fmt.Sprintf("repos/%s/actions/runs/%d/rerun-failed-jobs", nameWithOwner, runID))
+		return workflowRerunMsg{repoURL: repoURL, err: err}
+	}
+}
+
+// fetchWorkflowRuns returns the recent runs of every workflow on the default
+// branch and on the branch currently checked out. Runs are requested per
+// workflow, so a busy workflow can't crowd the others out.
+func fetchWorkflowRuns(gh *ghSession, repo GitRepo) ([]WorkflowRun, error) {
+	if err := gh.authError(); err != nil {
+		return nil, err
+	}
+	nameWithOwner, ok := repoNameWithOwner(repo.GitHubURL)
+	if !ok {
+		return nil, fmt.Errorf("not a GitHub repository")
+	}
+
+	defaultBranch := getDefaultBranch(repo.Directory)
+	if defaultBranch == "" {
+		output, err := gh.api("repos/" + nameWithOwner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default branch: %w", err)
+		}
+		var remote struct {
+			DefaultBranch string `json:"default_branch"`
+		}
+		if err := json.Unmarshal(output, &remote); err != nil {
+			return nil, fmt.Errorf("failed to parse repository: %w", err)
+		}
+		defaultBranch = remote.DefaultBranch
+	}
+
+	branches := []string{defaultBranch}
+	if current := getCurrentBranch(repo.Directory); current != "" && current != defaultBranch {
+		branches = append(branches, current)
+	}
+
+	workflows, err := listWorkflows(gh, nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []WorkflowRun
+	for _, branch := range branches {
+		workflowRuns := make([][]WorkflowRun, len(workflows))
+		errs := make([]error, len(workflows))
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, workflowFetchConcurrency)
+
+		for i, workflow := range workflows {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				workflowRuns[i], errs[i] = fetchRecentRuns(gh, nameWithOwner, workflow, branch)
+			}()
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		for _, recent := range workflowRuns {
+			runs = append(runs, recent...)
+		}
+	}
+
+	return runs, nil
+}
+
+// workflow is a GitHub Actions workflow defined in a repository.
+type workflow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// listWorkflows returns the repository's enabled workflows, sorted by name.
+func listWorkflows(gh *ghSession, nameWithOwner string) ([]workflow, error) {
+	output, err := gh.api("-X", "GET", "repos/"+nameWithOwner+"/actions/workflows", "-f", "per_page=100")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	var results struct {
+		Workflows []struct {
+			workflow
+			State string `json:"state"`
+		} `json:"workflows"`
+	}
+	if err := json.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse workflows: %w", err)
+	}
+
+	var workflows []workflow
+	for _, result := range results.Workflows {
+		if result.State == "active" {
+			workflows = append(workflows, result.workflow)
+		}
+	}
+	sort.SliceStable(workflows, func(i, j int) bool {
+		return workflows[i].Name < workflows[j].Name
+	})
+	return workflows, nil
+}
+
+// fetchRecentRuns returns the latest runs of one workflow on a branch,
+// newest first.
+func fetchRecentRuns(gh *ghSession, nameWithOwner string, wf workflow, branch string) ([]WorkflowRun, error) {
+	output, err := gh.api("-X", "GET", fmt.Sprintf("repos/%s/actions/workflows/%d/runs", nameWithOwner, wf.ID),
+		"-f", "branch="+branch,
+		"-f", fmt.Sprintf("per_page=%d", recentRunsPerWorkflow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s runs: %w", wf.Name, err)
+	}
+
+	var results struct {
+		WorkflowRuns []struct {
+			ID           int64     `json:"id"`
+			Event        string    `json:"event"`
+			Status       string    `json:"status"`
+			Conclusion   string    `json:"conclusion"`
+			HTMLURL      string    `json:"html_url"`
+			RunStartedAt time.Time `json:"run_started_at"`
+			UpdatedAt    time.Time `json:"updated_at"`
+		} `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow runs: %w", err)
+	}
+
+	var runs []WorkflowRun
+	for _, result := range results.WorkflowRuns {
+		runs = append(runs, WorkflowRun{
+			ID:         result.ID,
+			WorkflowID: wf.ID,
+			Workflow:   wf.Name,
+			Branch:     branch,
+			Event:      result.Event,
+			Status:     result.Status,
+			Conclusion: result.Conclusion,
+			URL:        result.HTMLURL,
+			StartedAt:  result.RunStartedAt,
+			UpdatedAt:  result.UpdatedAt,
+		})
+	}
+	return runs, nil
+}
+
+// renderWorkflowRun renders one run as a row. An older run of the workflow
+// above leaves the branch and workflow blank.
+func renderWorkflowRun(run WorkflowRun, repeat bool) string {
+	var icon string
+	var iconColor lipgloss.Color
+	switch {
+	case run.Status != "completed":
+		icon, iconColor = "●", lipgloss.Color("11")
+	case run.Conclusion == "success":
+		icon, iconColor = "✓", lipgloss.Color("2")
+	case run.Failed():
+		icon, iconColor = "✗", lipgloss.Color("9")
+	default:
+		icon, iconColor = "-", lipgloss.Color("8")
+	}
+
+	state := run.Conclusion
+	if run.Status != "completed" {
+		state = run.Status
+	}
+
+	branch, workflow := truncate(run.Branch, 16), truncate(run.Workflow, 28)
+	if repeat {
+		branch, workflow = "", ""
+	}
+	return fmt.Sprintf("%s %-16s %-28s %-12s %8s  %s ago",
+		lipgloss.NewStyle().Foreground(iconColor).Render(icon),
+		branch,
+		workflow,
+		state,
+		run.Duration(),
+		formatAge(time.Since(run.UpdatedAt)))
+}
+
+// formatAge renders a duration the way people say it: 45s, 12m, 3h, 2d.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
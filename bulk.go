@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultParallelism is how many repos bulk commands work on at once unless
+// the config or -j says otherwise.
+const defaultParallelism = 8
+
+// confirmation is a bulk action waiting for a yes, asked in the status bar
+// of whatever view started it.
+type confirmation struct {
+	prompt string
+	run    func(m model) (model, tea.Cmd)
+}
+
+// updateConfirm answers the pending confirmation. Keys other than yes, no
+// and quit are ignored, so a stray keystroke doesn't start anything.
+func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "y", "Y", "enter":
+		run := m.confirm.run
+		m.confirm = nil
+		return run(m)
+	case "n", "N", "esc":
+		m.confirm = nil
+	}
+	return m, nil
+}
+
+// toggleMark marks the selected repo for bulk actions, or unmarks it, and
+// moves on to the next row. Repos that aren't cloned can't be marked.
+func (m *model) toggleMark() {
+	if len(m.filteredRepos) == 0 || m.filteredRepos[m.cursor].RemoteOnly {
+		return
+	}
+	dir := m.filteredRepos[m.cursor].Directory
+	if m.marked[dir] {
+		delete(m.marked, dir)
+	} else {
+		if m.marked == nil {
+			m.marked = make(map[string]bool)
+		}
+		m.marked[dir] = true
+	}
+
+	if m.cursor < len(m.filteredRepos)-1 {
+		m.cursor++
+		visibleHeight := m.listVisibleHeight()
+		if m.cursor >= m.scrollOffset+visibleHeight {
+			m.scrollOffset = m.cursor - visibleHeight + 1
+		}
+	}
+}
+
+// bulkTargets is what sync and exec work on in the TUI: the marked repos if
+// there are any, whatever the search, or else the cloned repos in the list.
+func (m model) bulkTargets() (repos []GitRepo, marked bool) {
+	if len(m.marked) == 0 {
+		return localOnly(m.filteredRepos), false
+	}
+	for _, repo := range m.repos {
+		if m.marked[repo.Directory] {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, true
+}
+
+// describeTargets counts bulkTargets for prompts, e.g. "3 marked repos".
+func describeTargets(repos []GitRepo, marked bool) string {
+	if marked {
+		return fmt.Sprintf("%d marked %s", len(repos), plural(len(repos), "repo"))
+	}
+	return fmt.Sprintf("%d %s", len(repos), plural(len(repos), "repo"))
+}
+
+// filterReposForCLI applies a search, as typed in the TUI, to the repos a
+// subcommand works on. An empty search keeps them all.
+func filterReposForCLI(repos []GitRepo, config *Config, search string) ([]GitRepo, error) {
+	m := model{
+		repos:   repos,
+		config:  config,
+		prCache: loadPersistedPRCache(), // For prs:, label: and body:
+	}
+	if name, found := strings.CutPrefix(search, "@"); found {
+		saved, ok := config.savedSearch(name)
+		if !ok {
+			return nil, fmt.Errorf("no saved search named %q", name)
+		}
+		if saved.Mode == "pr" {
+			return nil, fmt.Errorf("saved search %q runs in PR mode", name)
+		}
+		m.useSavedSearch(saved)
+	} else {
+		m.searchInput.setValue(search)
+	}
+
+	m.filterRepos()
+	if m.queryErr != nil {
+		return nil, fmt.Errorf("invalid search: %w", m.queryErr)
+	}
+	if m.query.usesLocalStatus() {
+		msg := loadLocalStatusCmd(repos, true)().(localStatusLoadedMsg)
+		m.mergeLocalStatuses(msg.statuses)
+		m.localStatusComplete = true
+		m.filterRepos()
+	}
+
+	return localOnly(m.filteredRepos), nil
+}
+
+// localOnly drops the repos that are listed from an org but not cloned.
+func localOnly(repos []GitRepo) []GitRepo {
+	var local []GitRepo
+	for _, repo := range repos {
+		if !repo.RemoteOnly {
+			local = append(local, repo)
+		}
+	}
+	return local
+}
+
+// subcommandRepos is what subcommands work on: the repos found, or the
+// current repo when qgh runs inside one without nested repos.
+func subcommandRepos(repos []GitRepo, searchDir string) []GitRepo {
+	if len(repos) > 0 || !isGitRepository(searchDir) {
+		return repos
+	}
+	current, err := getCurrentRepoInfo(searchDir)
+	if err != nil {
+		return repos
+	}
+	return []GitRepo{*current}
+}
+
+// parallelism is how many repos bulk commands work on at once.
+func (c *Config) parallelism() int {
+	if c.Parallelism > 0 {
+		return c.Parallelism
+	}
+	return defaultParallelism
+}
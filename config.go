@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultCloneLayout places clones under the workspace by owner and name.
+const defaultCloneLayout = "<root>/<owner>/<name>"
+
+// Config is read from $XDG_CONFIG_HOME/qgh/config.json (or the platform
+// equivalent); QGH_CONFIG points at a different file.
+type Config struct {
+	// Orgs whose repositories can be searched and cloned in remote mode
+	Orgs []string `json:"orgs"`
+
+	// CloneLayout is where remote repos are cloned, built from the
+	// placeholders <root>, <owner> and <name>
+	CloneLayout string `json:"cloneLayout"`
+
+	// RefreshInterval is how often PRs are refreshed while qgh is open, as a
+	// Go duration such as "5m"; "0" turns periodic refresh off
+	RefreshInterval string `json:"refreshInterval"`
+
+	// StatusColumns shows the branch and working tree columns at startup;
+	// Ctrl+G toggles them
+	StatusColumns bool `json:"statusColumns"`
+
+	// Parallelism is how many repos qgh sync and qgh exec work on at once,
+	// with 0 meaning defaultParallelism
+	Parallelism int `json:"parallelism"`
+
+	// Searches are saved searches, picked with Ctrl+S or run as `qgh @name`
+	Searches []SavedSearch `json:"searches"`
+}
+
+// SavedSearch is a named search and the mode it runs in.
+type SavedSearch struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Mode  string `json:"mode"`  // "pr" for PR mode, "" for the repo list
+	Regex bool   `json:"regex"` // Query is an RE2 pattern
+}
+
+func configPath() (string, error) {
+	if path := os.Getenv("QGH_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "qgh", "config.json"), nil
+}
+
+// loadConfig reads the config file, falling back to defaults when it
+// doesn't exist.
+func loadConfig() (*Config, error) {
+	config := &Config{}
+
+	path, err := configPath()
+	if err != nil {
+		return config, fmt.Errorf("failed to locate config: %w", err)
+	}
+	var configErr error
+	if err := readJSONFile(path, config); err != nil {
+		configErr = fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if config.CloneLayout == "" {
+		config.CloneLayout = defaultCloneLayout
+	}
+	if config.RefreshInterval != "" {
+		if interval, err := time.ParseDuration(config.RefreshInterval); err != nil {
+			config.RefreshInterval = ""
+			configErr = fmt.Errorf("invalid refreshInterval in %s: %w", path, err)
+		} else if interval < 0 {
+			config.RefreshInterval = ""
+			configErr = fmt.Errorf("invalid refreshInterval in %s: must not be negative, use \"0\" to turn refreshing off", path)
+		}
+	}
+
+	if config.Parallelism < 0 {
+		config.Parallelism = 0
+		configErr = fmt.Errorf("invalid parallelism in %s: must not be negative", path)
+	}
+
+	// Keep the usable saved searches, reporting the first bad one
+	var searches []SavedSearch
+	names := make(map[string]bool)
+	for _, search := range config.Searches {
+		var err error
+		switch {
+		case search.Name == "" || strings.ContainsAny(search.Name, " @"):
+			err = fmt.Errorf("saved search name %q must be non-empty, without spaces or @", search.Name)
+		case names[search.Name]:
+			err = fmt.Errorf("duplicate saved search %q", search.Name)
+		case search.Mode != "" && search.Mode != "pr":
+			err = fmt.Errorf("saved search %q: mode must be \"pr\" or left out", search.Name)
+		}
+		if err != nil {
+			if configErr == nil {
+				configErr = fmt.Errorf("invalid search in %s: %w", path, err)
+			}
+			continue
+		}
+		names[search.Name] = true
+		searches = append(searches, search)
+	}
+	config.Searches = searches
+	return config, configErr
+}
+
+// savedSearch looks up a saved search by name.
+func (c *Config) savedSearch(name string) (SavedSearch, bool) {
+	for _, search := range c.Searches {
+		if search.Name == name {
+			return search, true
+		}
+	}
+	return SavedSearch{}, false
+}
+
+// refreshEvery is the periodic refresh interval, 0 when it's turned off.
+func (c *Config) refreshEvery() time.Duration {
+	if c.RefreshInterval == "" {
+		return defaultRefreshInterval
+	}
+	interval, _ := time.ParseDuration(c.RefreshInterval)
+	return interval
+}
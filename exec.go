@@ -0,0 +1,692 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+)
+
+// execOutputCap is how many lines of output the TUI keeps per repo. Older
+// lines are dropped.
+const execOutputCap = 10000
+
+// execBatchInterval is how long the TUI gathers progress into one message,
+// so a chatty command redraws the screen a few times a second rather than
+// once a line.
+const execBatchInterval = 50 * time.Millisecond
+
+// execResult is how a command ended in one repo.
+type execResult struct {
+	exitCode int
+	err      error // Set when the command couldn't start or was killed
+	duration time.Duration
+}
+
+// execEventMsg reports progress on one repo: the command starting, a line
+// of its output, then its result.
+type execEventMsg struct {
+	index   int
+	started bool
+	line    string
+	result  *execResult
+}
+
+// execEventsMsg is the progress gathered over execBatchInterval, in order.
+type execEventsMsg struct {
+	events []execEventMsg
+	done   bool // Every repo has finished
+}
+
+// execRun is a command run across repos, in the TUI.
+type execRun struct {
+	command      string
+	repos        []GitRepo
+	running      []bool
+	outputs      [][]string
+	results      []*execResult
+	events       chan execEventMsg
+	finished     int
+	done         bool
+	cursor       int
+	scrollOffset int
+
+	// The output pane of the repo under the cursor
+	paneOpen   bool
+	paneOffset int
+	paneFollow bool // Keep the newest output in view
+}
+
+// runExec runs a shell command in each repo, parallelism at a time, sending
+// its output and exit status on events and closing it when they're all
+// done.
+func runExec(repos []GitRepo, command string, parallelism int, events chan<- execEventMsg) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, dir string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			events <- execEventMsg{index: i, started: true}
+			result := execInRepo(dir, command, func(line string) {
+				events <- execEventMsg{index: i, line: line}
+			})
+			events <- execEventMsg{index: i, result: &result}
+		}(i, repo.Directory)
+	}
+	wg.Wait()
+	close(events)
+}
+
+// execInRepo runs a shell command in dir, passing each line of its output,
+// stdout and stderr interleaved, to emit. Stdin is empty so nothing waits
+// for input.
+func execInRepo(dir, command string, emit func(line string)) execResult {
+	cmd := shellCommand(command)
+	cmd.Dir = dir
+	output := &lineWriter{emit: emit}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	start := time.Now()
+	err := cmd.Run()
+	output.flush()
+
+	result := execResult{duration: time.Since(start)}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.exitCode = exitErr.ExitCode()
+		if result.exitCode < 0 {
+			// Killed by a signal
+			result.err = err
+		}
+	case err != nil:
+		result.exitCode = -1
+		result.err = err
+	}
+	return result
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// lineWriter splits what's written to it into lines.
+type lineWriter struct {
+	emit    func(line string)
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(strings.TrimSuffix(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush passes on a last line without a trailing newline.
+func (w *lineWriter) flush() {
+	if len(w.partial) > 0 {
+		w.emit(strings.TrimSuffix(string(w.partial), "\r"))
+		w.partial = nil
+	}
+}
+
+func (r execResult) failed() bool {
+	return r.exitCode != 0 || r.err != nil
+}
+
+// describe says how the command ended, e.g. "exit 2 in 1.3s".
+func (r execResult) describe() string {
+	took := r.duration.Round(100 * time.Millisecond)
+	switch {
+	case r.err != nil:
+		return strings.Join(strings.Fields(r.err.Error()), " ")
+	case r.exitCode == 0:
+		return fmt.Sprintf("ok in %s", took)
+	}
+	return fmt.Sprintf("exit %d in %s", r.exitCode, took)
+}
+
+// execSummary counts the results, e.g. "8 succeeded, 2 failed".
+func execSummary(results []*execResult) string {
+	succeeded, failed := 0, 0
+	for _, result := range results {
+		switch {
+		case result == nil:
+		case result.failed():
+			failed++
+		default:
+			succeeded++
+		}
+	}
+	var parts []string
+	if succeeded > 0 {
+		parts = append(parts, fmt.Sprintf("%d succeeded", succeeded))
+	}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// waitForExecEvents waits for progress, then gathers whatever else arrives
+// within execBatchInterval.
+func waitForExecEvents(events <-chan execEventMsg) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return execEventsMsg{done: true}
+		}
+		batch := []execEventMsg{event}
+		deadline := time.After(execBatchInterval)
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return execEventsMsg{events: batch, done: true}
+				}
+				batch = append(batch, event)
+			case <-deadline:
+				return execEventsMsg{events: batch}
+			}
+		}
+	}
+}
+
+// openExec asks for a command to run in the marked repos or the ones in the
+// list, or shows the run that's still going.
+func (m model) openExec() (model, tea.Cmd) {
+	if m.exec != nil && !m.exec.done {
+		m.currentView = execView
+		return m, nil
+	}
+	if repos, _ := m.bulkTargets(); len(repos) == 0 {
+		return m, nil
+	}
+	// The last command stays in the prompt, ready to run again
+	m.execPrompting = true
+	m.currentView = execView
+	return m, nil
+}
+
+// confirmExec asks before running the prompt's command, saying where and
+// how.
+func (m model) confirmExec(parallelism int) (model, tea.Cmd) {
+	command := strings.TrimSpace(m.execInput.value())
+	repos, marked := m.bulkTargets()
+	if command == "" || len(repos) == 0 {
+		return m, nil
+	}
+
+	how := fmt.Sprintf("%d at a time", parallelism)
+	switch {
+	case parallelism == 1:
+		how = "one at a time"
+	case parallelism >= len(repos):
+		how = "all at once"
+	}
+	m.confirm = &confirmation{
+		prompt: fmt.Sprintf("Run %q in %s, %s?", command, describeTargets(repos, marked), how),
+		run: func(m model) (model, tea.Cmd) {
+			return m.startExec(command, repos, parallelism)
+		},
+	}
+	return m, nil
+}
+
+// startExec runs a command in repos in the background and shows its
+// progress.
+func (m model) startExec(command string, repos []GitRepo, parallelism int) (model, tea.Cmd) {
+	run := &execRun{
+		command:    command,
+		repos:      repos,
+		running:    make([]bool, len(repos)),
+		outputs:    make([][]string, len(repos)),
+		results:    make([]*execResult, len(repos)),
+		events:     make(chan execEventMsg, 256),
+		paneFollow: true,
+	}
+	m.exec = run
+	m.execPrompting = false
+	m.startJob(jobExec)
+	go runExec(repos, command, parallelism, run.events)
+	return m, waitForExecEvents(run.events)
+}
+
+func (run *execRun) apply(event execEventMsg) {
+	switch {
+	case event.result != nil:
+		run.running[event.index] = false
+		run.results[event.index] = event.result
+		run.finished++
+	case event.started:
+		run.running[event.index] = true
+	default:
+		output := append(run.outputs[event.index], ansi.Strip(event.line))
+		if len(output) > execOutputCap {
+			output = slices.Delete(output, 0, len(output)-execOutputCap)
+		}
+		run.outputs[event.index] = output
+	}
+}
+
+// execFooter is the key help under the prompt, the results or a repo's
+// output, whichever is showing.
+func (m model) execFooter() string {
+	switch {
+	case m.execPrompting:
+		return "Enter to run in parallel, Alt+Enter to run in one repo at a time, Esc to cancel, Ctrl+C to quit"
+	case m.exec.paneOpen:
+		return "Use ↑/↓ to scroll, PgUp/PgDn for pages, Home/End for the start and end, Esc to go back, Ctrl+C to quit"
+	case m.exec.done:
+		return "Use ↑/↓ to navigate, Enter for output, Esc to go back, Alt+X in the list to run another command, Ctrl+C to quit"
+	default:
+		return "Use ↑/↓ to navigate, Enter for output, Esc to go back while the command carries on, Ctrl+C to quit"
+	}
+}
+
+func (m model) execVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + scroll indicators(2) + newline before footer(1) + status bar(1) = 7 lines, and the footer
+	visibleHeight := m.terminalHeight - 7 - m.footerHeight(m.execFooter())
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+	return visibleHeight
+}
+
+func (m model) updateExecView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.execPrompting {
+		return m.updateExecPrompt(msg)
+	}
+	if m.exec.paneOpen {
+		return m.updateExecPane(msg)
+	}
+
+	run := m.exec
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		// A running command carries on in the background
+		m.currentView = listView
+	case "enter":
+		run.paneOpen = true
+		run.paneOffset = 0
+		run.paneFollow = true
+	case "up":
+		if run.cursor > 0 {
+			run.cursor--
+			if run.cursor < run.scrollOffset {
+				run.scrollOffset = run.cursor
+			}
+		}
+	case "down":
+		if run.cursor < len(run.repos)-1 {
+			run.cursor++
+			visibleHeight := m.execVisibleHeight()
+			if run.cursor >= run.scrollOffset+visibleHeight {
+				run.scrollOffset = run.cursor - visibleHeight + 1
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m model) updateExecPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Paste {
+		m.execInput.update(msg)
+		return m, nil
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.execPrompting = false
+		m.currentView = listView
+	case "enter":
+		return m.confirmExec(m.config.parallelism())
+	case "alt+enter":
+		// One repo at a time, for commands that don't like company
+		return m.confirmExec(1)
+	default:
+		m.execInput.update(msg)
+	}
+	return m, nil
+}
+
+func (m model) updateExecPane(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	run := m.exec
+	lines := len(run.outputs[run.cursor])
+	visibleHeight := m.execVisibleHeight()
+	maxOffset := max(lines-visibleHeight, 0)
+	offset := run.paneOffset
+	if run.paneFollow {
+		offset = maxOffset
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "enter":
+		run.paneOpen = false
+		return m, nil
+	case "up":
+		offset--
+	case "down":
+		offset++
+	case "pgup":
+		offset -= visibleHeight
+	case "pgdown":
+		offset += visibleHeight
+	case "home":
+		offset = 0
+	case "end":
+		offset = maxOffset
+	}
+	run.paneOffset = min(max(offset, 0), maxOffset)
+	// Scrolling to the bottom follows new output again
+	run.paneFollow = run.paneOffset == maxOffset
+	return m, nil
+}
+
+// title is the command being run, on one line.
+func (run *execRun) title() string {
+	return "$ " + strings.Join(strings.Fields(run.command), " ")
+}
+
+func (m model) renderExecView() string {
+	if m.execPrompting {
+		return m.renderExecPrompt()
+	}
+	if m.exec.paneOpen {
+		return m.renderExecPane()
+	}
+
+	var b strings.Builder
+	run := m.exec
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("62")).
+		Foreground(lipgloss.Color("230"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	runningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("2"))
+
+	failedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9"))
+
+	header := fmt.Sprintf("%s: %d/%d done", run.title(), run.finished, len(run.repos))
+	if summary := execSummary(run.results); summary != "" {
+		header += " - " + summary
+	}
+	b.WriteString(headerStyle.Render(header))
+	b.WriteString("\n\n")
+
+	if run.scrollOffset > 0 {
+		b.WriteString("↑ (more above)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	labels := calculateMinimalPaths(run.repos)
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, uniseg.StringWidth(label))
+	}
+
+	endIdx := min(run.scrollOffset+m.execVisibleHeight(), len(run.repos))
+	for i := run.scrollOffset; i < endIdx; i++ {
+		label := labels[i] + strings.Repeat(" ", labelWidth-uniseg.StringWidth(labels[i]))
+		var state string
+		style := runningStyle
+		switch result := run.results[i]; {
+		case result != nil && result.failed():
+			state, style = result.describe(), failedStyle
+		case result != nil:
+			state, style = result.describe(), okStyle
+		case run.running[i]:
+			state = "running..."
+		default:
+			state, style = "queued", dimStyle
+		}
+		// The latest line says what a repo is up to, or why it failed
+		var last string
+		if output := run.outputs[i]; len(output) > 0 {
+			last = "  " + strings.TrimSpace(output[len(output)-1])
+		}
+
+		line := label + "  " + style.Render(state) + dimStyle.Render(last)
+		if i == run.cursor {
+			line = selectedStyle.Render(label + "  " + state + last)
+		}
+		if m.terminalWidth > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if endIdx < len(run.repos) {
+		b.WriteString("↓ (more below)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.execFooter()))
+
+	return b.String()
+}
+
+func (m model) renderExecPrompt() string {
+	var b strings.Builder
+	repos, marked := m.bulkTargets()
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	b.WriteString(headerStyle.Render("Run a command in " + describeTargets(repos, marked)))
+	b.WriteString("\n\n")
+	b.WriteString("$ " + m.execInput.view())
+	b.WriteString("\n\n")
+
+	// Where it'll run, as far as fits
+	visibleHeight := max(m.execVisibleHeight()-2, 1)
+	labels := calculateMinimalPaths(repos)
+	for i, label := range labels {
+		if i == visibleHeight && len(labels) > visibleHeight+1 {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("... and %d more", len(labels)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(dimStyle.Render(label))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.execFooter()))
+
+	return b.String()
+}
+
+func (m model) renderExecPane() string {
+	var b strings.Builder
+	run := m.exec
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	state := "queued"
+	if result := run.results[run.cursor]; result != nil {
+		state = result.describe()
+	} else if run.running[run.cursor] {
+		state = "running..."
+	}
+	label := calculateMinimalPaths(run.repos)[run.cursor]
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s: %s - %s", label, run.title(), state)))
+	b.WriteString("\n\n")
+
+	output := run.outputs[run.cursor]
+	visibleHeight := m.execVisibleHeight()
+	offset := run.paneOffset
+	if run.paneFollow {
+		offset = max(len(output)-visibleHeight, 0)
+	}
+
+	if offset > 0 {
+		b.WriteString("↑ (more above)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	endIdx := min(offset+visibleHeight, len(output))
+	if len(output) == 0 {
+		b.WriteString(dimStyle.Render("(no output)"))
+		b.WriteString("\n")
+	}
+	for _, line := range output[offset:endIdx] {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if m.terminalWidth > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if endIdx < len(output) {
+		b.WriteString("↓ (more below)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.execFooter()))
+
+	return b.String()
+}
+
+// runExecCommand implements `qgh exec [-j N] [-s] [search] -- <command>`,
+// returning the exit code: 1 if the command failed in any repo.
+func runExecCommand(repos []GitRepo, config *Config, args []string) int {
+	flags := flag.NewFlagSet("exec", flag.ExitOnError)
+	parallelism := flags.Int("j", config.parallelism(), "Number of repos to run the command in at once")
+	sequential := flags.Bool("s", false, "Run the command in one repo at a time, same as -j 1")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: qgh exec [-j N] [-s] [search] -- <command>")
+		flags.PrintDefaults()
+	}
+	sep := slices.Index(args, "--")
+	if sep < 0 || sep == len(args)-1 {
+		flags.Usage()
+		return 2
+	}
+	flags.Parse(args[:sep])
+	if *sequential {
+		*parallelism = 1
+	}
+	if *parallelism < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -j must be at least 1")
+		return 2
+	}
+	// Like ssh, the words after -- make up one shell command
+	command := strings.Join(args[sep+1:], " ")
+
+	repos, err := filterReposForCLI(repos, config, strings.Join(flags.Args(), " "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(repos) == 0 {
+		fmt.Println("No repositories to run in.")
+		return 0
+	}
+
+	labels := calculateMinimalPaths(repos)
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, uniseg.StringWidth(label))
+	}
+	// The prefix column, padded to the display width of the widest label
+	prefixes := make([]string, len(labels))
+	for i, label := range labels {
+		prefixes[i] = label + strings.Repeat(" ", labelWidth-uniseg.StringWidth(label)) + " | "
+	}
+	results := make([]*execResult, len(repos))
+	events := make(chan execEventMsg, 256)
+	go runExec(repos, command, *parallelism, events)
+
+	// Prefix each line with its repo, so parallel output stays readable
+	for event := range events {
+		switch {
+		case event.result != nil:
+			results[event.index] = event.result
+			if event.result.failed() {
+				fmt.Println(prefixes[event.index] + event.result.describe())
+			}
+		case !event.started:
+			fmt.Println(prefixes[event.index] + event.line)
+		}
+	}
+
+	fmt.Printf("\nRan in %d %s: %s\n", len(repos), plural(len(repos), "repo"), execSummary(results))
+	var failed []string
+	for i, result := range results {
+		if result.failed() {
+			failed = append(failed, fmt.Sprintf("  %s: %s", labels[i], result.describe()))
+		}
+	}
+	if len(failed) == 0 {
+		return 0
+	}
+	fmt.Printf("\nFailed:\n%s\n", strings.Join(failed, "\n"))
+	return 1
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWaitForExecEvents(t *testing.T) {
+	events := make(chan execEventMsg, 8)
+	for i := range 3 {
+		events <- execEventMsg{index: i, line: "out"}
+	}
+
+	// What's queued arrives as one message, without waiting for the end
+	msg := waitForExecEvents(events)().(execEventsMsg)
+	if len(msg.events) != 3 || msg.done {
+		t.Fatalf("first batch = %d events, done %v, want 3 events still running", len(msg.events), msg.done)
+	}
+	for i, event := range msg.events {
+		if event.index != i {
+			t.Errorf("event %d is for repo %d, out of order", i, event.index)
+		}
+	}
+
+	events <- execEventMsg{index: 3, started: true}
+	close(events)
+	msg = waitForExecEvents(events)().(execEventsMsg)
+	if len(msg.events) != 1 || !msg.done {
+		t.Errorf("last batch = %d events, done %v, want 1 event and done", len(msg.events), msg.done)
+	}
+	if msg := waitForExecEvents(events)().(execEventsMsg); len(msg.events) != 0 || !msg.done {
+		t.Errorf("after the end = %+v, want done with no events", msg)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{emit: func(line string) { lines = append(lines, line) }}
+	w.Write([]byte("one\r\ntw"))
+	w.Write([]byte("o\n\nthree"))
+	w.flush()
+	if want := []string{"one", "two", "", "three"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ghSession is the GitHub identity established once per run. Every fetch
+// shares it instead of re-running `gh auth status` and `gh api user`.
+type ghSession struct {
+	Host   string
+	Login  string
+	Scopes []string
+	Err    error // Why the session is unauthenticated, nil when logged in
+
+	mu           sync.Mutex
+	rateLimits   map[string]rateLimit // Keyed by X-RateLimit-Resource
+	lastResource string
+}
+
+type ghSessionMsg struct {
+	session *ghSession
+}
+
+func connectGitHubCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ghSessionMsg{session: newGHSession()}
+	}
+}
+
+// newGHSession asks the GitHub CLI who we are. A single `gh api --include user`
+// call yields the login from the body and the token scopes from the headers.
+func newGHSession() *ghSession {
+	host := githubHost()
+	s := &ghSession{Host: host}
+
+	if _, err := exec.LookPath("gh"); err != nil {
+		s.Err = fmt.Errorf("GitHub CLI (gh) not installed")
+		return s
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("gh", "api", "--hostname", host, "--include", "user")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	resp, parseErr := parseGHResponse(out)
+	if err != nil {
+		// Only a rejected or missing token means logging in would help
+		switch {
+		case parseErr == nil && resp.Status == http.StatusUnauthorized, isGHAuthFailure(stderr.String()):
+			s.Err = errGHNotAuthenticated
+		case parseErr == nil:
+			s.Err = fmt.Errorf("failed to read GitHub user: HTTP %d: %s", resp.Status, ghErrorText(err, stderr.String()))
+		default:
+			s.Err = fmt.Errorf("failed to reach GitHub: %s", ghErrorText(err, stderr.String()))
+		}
+		return s
+	}
+	if parseErr != nil {
+		s.Err = fmt.Errorf("failed to read GitHub user: %w", parseErr)
+		return s
+	}
+	s.recordRateLimit(resp.Header)
+	header, body := resp.Header, resp.Body
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil || user.Login == "" {
+		s.Err = fmt.Errorf("failed to read GitHub user")
+		return s
+	}
+	s.Login = user.Login
+
+	for _, scope := range strings.Split(header.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			s.Scopes = append(s.Scopes, scope)
+		}
+	}
+
+	return s
+}
+
+// errGHNotAuthenticated is the session error when gh has no token for the
+// host or GitHub rejects it.
+var errGHNotAuthenticated = errors.New("GitHub CLI not authenticated, run 'gh auth login'")
+
+// isGHAuthFailure reports whether gh's error output says it has no usable
+// token, as opposed to GitHub being unreachable.
+func isGHAuthFailure(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, marker := range []string{"gh auth login", "http 401", "bad credentials"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ghSession) authenticated() bool {
+	return s != nil && s.Login != ""
+}
+
+// authError returns the reason fetches can't run, or nil when they can.
+func (s *ghSession) authError() error {
+	if s == nil {
+		return fmt.Errorf("GitHub session not established")
+	}
+	if s.Err != nil {
+		return s.Err
+	}
+	return nil
+}
+
+// parseGHResponse splits the output of `gh api --include` into status,
+// headers and body. The headers are parsed by hand because gh prints the
+// decoded body, so Content-Length and Content-Encoding no longer describe
+// what follows.
+func parseGHResponse(out []byte) (*ghResponse, error) {
+	out = bytes.ReplaceAll(out, []byte("\r\n"), []byte("\n"))
+	head, body, found := bytes.Cut(out, []byte("\n\n"))
+	if !found {
+		return nil, fmt.Errorf("malformed response from gh")
+	}
+
+	lines := strings.Split(string(head), "\n")
+	statusFields := strings.Fields(lines[0])
+	if len(statusFields) < 2 || !strings.HasPrefix(statusFields[0], "HTTP/") {
+		return nil, fmt.Errorf("malformed status line from gh: %q", lines[0])
+	}
+	status, err := strconv.Atoi(statusFields[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed status line from gh: %q", lines[0])
+	}
+
+	header := http.Header{}
+	for _, line := range lines[1:] {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	}
+	return &ghResponse{Status: status, Header: header, Body: body}, nil
+}
+
+// githubHost is the GitHub instance qgh talks to: GH_HOST, as for gh
+// itself, or github.com.
+func githubHost() string {
+	if host := os.Getenv("GH_HOST"); host != "" {
+		return host
+	}
+	return "github.com"
+}
+
+// isGitHubHost reports whether a remote's host is GitHub: github.com itself
+// or the host set with GH_HOST.
+func isGitHubHost(host string) bool {
+	return strings.EqualFold(host, "github.com") || strings.EqualFold(host, githubHost())
+}
+
+// githubRepoURL is the web URL of a repository on githubHost.
+func githubRepoURL(nameWithOwner string) string {
+	return "https://" + githubHost() + "/" + nameWithOwner
+}
+
+// repoURLRegex matches repository URLs on any host, so Enterprise URLs built
+// by githubRepoURL parse too.
+var repoURLRegex = regexp.MustCompile(`^https://[^/]+/([^/]+)/([^/]+)`)
+
+// repoNameWithOwner extracts "owner/repo" from a GitHub repository URL.
+func repoNameWithOwner(repoURL string) (string, bool) {
+	matches := repoURLRegex.FindStringSubmatch(repoURL)
+	if len(matches) != 3 {
+		return "", false
+	}
+	return matches[1] + "/" + matches[2], true
+}
+
+const (
+	ghMaxAttempts      = 4
+	ghInitialBackoff   = time.Second
+	ghMaxRateLimitWait = time.Minute // Longer waits fail fast instead of hanging the UI
+)
+
+// rateLimit is the quota GitHub last reported for one API resource.
+type rateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// errGHNotFound is wrapped by api errors for 404 responses, which callers
+// often treat as "nothing there" rather than a failure.
+var errGHNotFound = errors.New("not found")
+
+// rateLimitError is returned when the quota is exhausted for longer than
+// we're willing to wait.
+type rateLimitError struct {
+	Reset time.Time
+}
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("GitHub rate limit exceeded, resets at %s", e.Reset.Format("15:04:05"))
+}
+
+// ghResponse is one HTTP exchange as printed by `gh api --include`.
+type ghResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// api runs `gh api --include` against the session's host, honouring the
+// rate-limit headers and retrying transient failures with exponential backoff.
+// When gh fails a 2xx response, as it does for GraphQL errors, the body is
+// returned along with the error so partial data can still be used.
+func (s *ghSession) api(args ...string) ([]byte, error) {
+	args = append([]string{"api", "--hostname", s.Host, "--include"}, args...)
+
+	backoff := ghInitialBackoff
+	var lastErr error
+	for attempt := 0; attempt < ghMaxAttempts; attempt++ {
+		var stderr bytes.Buffer
+		cmd := exec.Command("gh", args...)
+		cmd.Stderr = &stderr
+		out, runErr := cmd.Output()
+
+		resp, parseErr := parseGHResponse(out)
+		if parseErr != nil {
+			// No HTTP response at all: worth retrying after a network failure,
+			// but not when gh is missing or rejected the arguments
+			lastErr = fmt.Errorf("gh api: %s", ghErrorText(runErr, stderr.String()))
+			if !isTransientGHError(stderr.String()) {
+				return nil, lastErr
+			}
+			if attempt < ghMaxAttempts-1 {
+				time.Sleep(backoff)
+				backoff *= 2
+			}
+			continue
+		}
+		s.recordRateLimit(resp.Header)
+
+		if runErr == nil && resp.Status < 300 {
+			return resp.Body, nil
+		}
+		lastErr = fmt.Errorf("gh api: HTTP %d: %s", resp.Status, ghErrorText(runErr, stderr.String()))
+		if resp.Status < 300 {
+			return resp.Body, lastErr
+		}
+
+		if resp.Status == http.StatusNotFound {
+			return nil, fmt.Errorf("gh api: %w", errGHNotFound)
+		}
+
+		wait, retry := retryDelay(resp, backoff)
+		if !retry {
+			if resp.Status == http.StatusForbidden || resp.Status == http.StatusTooManyRequests {
+				if reset, ok := rateLimitReset(resp.Header); ok {
+					return nil, &rateLimitError{Reset: reset}
+				}
+			}
+			return nil, lastErr
+		}
+		if attempt < ghMaxAttempts-1 {
+			time.Sleep(wait)
+			backoff *= 2
+		}
+	}
+	return nil, lastErr
+}
+
+// run executes a gh subcommand that doesn't expose response headers, retrying
+// when its error output looks like a rate limit or a transient server error.
+func (s *ghSession) run(args ...string) ([]byte, error) {
+	backoff := ghInitialBackoff
+	var lastErr error
+	for attempt := 0; attempt < ghMaxAttempts; attempt++ {
+		var stderr bytes.Buffer
+		cmd := exec.Command("gh", args...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err == nil {
+			return out, nil
+		}
+		lastErr = fmt.Errorf("%s", ghErrorText(err, stderr.String()))
+
+		if !isTransientGHError(stderr.String()) {
+			return nil, lastErr
+		}
+		if attempt < ghMaxAttempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return nil, lastErr
+}
+
+// retryDelay decides whether a failed response is worth retrying and how long
+// to wait first. Secondary limits send Retry-After; primary limits send a
+// reset time once the remaining quota hits zero.
+func retryDelay(resp *ghResponse, backoff time.Duration) (time.Duration, bool) {
+	switch {
+	case resp.Status == http.StatusForbidden || resp.Status == http.StatusTooManyRequests:
+		if after := resp.Header.Get("Retry-After"); after != "" {
+			if secs, err := strconv.Atoi(after); err == nil {
+				wait := time.Duration(secs) * time.Second
+				return wait, wait <= ghMaxRateLimitWait
+			}
+		}
+		if reset, ok := rateLimitReset(resp.Header); ok {
+			wait := max(time.Until(reset)+time.Second, time.Second)
+			return wait, wait <= ghMaxRateLimitWait
+		}
+		if strings.Contains(strings.ToLower(string(resp.Body)), "secondary rate limit") {
+			// Secondary limits without Retry-After ask for at least a minute
+			return ghMaxRateLimitWait, true
+		}
+		return 0, false
+	case resp.Status >= 500:
+		return backoff, true
+	}
+	return 0, false
+}
+
+// rateLimitReset reports when an exhausted quota resets.
+func rateLimitReset(header http.Header) (time.Time, bool) {
+	if header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
+
+func isTransientGHError(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, marker := range []string{
+		"rate limit",
+		"http 502", "http 503", "http 504",
+		"timeout", "connection reset", "connection refused", "eof",
+		"error connecting to", "dial tcp",
+	} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func ghErrorText(err error, stderr string) string {
+	if msg := strings.TrimSpace(stderr); msg != "" {
+		return msg
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return "unknown error"
+}
+
+func (s *ghSession) recordRateLimit(header http.Header) {
+	limit, err1 := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return
+	}
+	resource := header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rateLimits == nil {
+		s.rateLimits = make(map[string]rateLimit)
+	}
+	s.rateLimits[resource] = rateLimit{
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+	s.lastResource = resource
+}
+
+// quota returns the most recently reported rate limit, if any.
+func (s *ghSession) quota() (rateLimit, bool) {
+	if s == nil {
+		return rateLimit{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rl, ok := s.rateLimits[s.lastResource]
+	return rl, ok
+}
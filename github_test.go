@@ -0,0 +1,177 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIsGHAuthFailure(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"To get started with GitHub CLI, please run:  gh auth login", true},
+		{"HTTP 401: Bad credentials (https://api.github.com/user)", true},
+		{"error connecting to api.github.com\ncheck your internet connection or https://githubstatus.com", false},
+		{"HTTP 502: Bad Gateway (https://api.github.com/user)", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isGHAuthFailure(tt.stderr); got != tt.want {
+			t.Errorf("isGHAuthFailure(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+func TestRepoNameWithOwner(t *testing.T) {
+	tests := []struct {
+		url, want string
+		ok        bool
+	}{
+		{"https://github.com/acme/web", "acme/web", true},
+		{"https://ghe.acme.com/acme/web", "acme/web", true},
+		{"https://github.com/acme", "", false},
+		{"Non-GitHub", "", false},
+		{"N/A", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := repoNameWithOwner(tt.url)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("repoNameWithOwner(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGitHubRepoURL(t *testing.T) {
+	t.Setenv("GH_HOST", "")
+	if got := githubRepoURL("acme/web"); got != "https://github.com/acme/web" {
+		t.Errorf("githubRepoURL = %q", got)
+	}
+	t.Setenv("GH_HOST", "ghe.acme.com")
+	if got := githubRepoURL("acme/web"); got != "https://ghe.acme.com/acme/web" {
+		t.Errorf("githubRepoURL with GH_HOST = %q", got)
+	}
+}
+
+func TestParseGHResponse(t *testing.T) {
+	out := "HTTP/2.0 200 OK\r\nX-Oauth-Scopes: repo, read:org\r\nX-Ratelimit-Remaining: 4999\r\n\r\n{\"login\":\"octocat\"}"
+	resp, err := parseGHResponse([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != http.StatusOK {
+		t.Errorf("Status = %d, want 200", resp.Status)
+	}
+	if got := resp.Header.Get("X-OAuth-Scopes"); got != "repo, read:org" {
+		t.Errorf("X-OAuth-Scopes = %q", got)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "4999" {
+		t.Errorf("X-RateLimit-Remaining = %q", got)
+	}
+	if got := string(resp.Body); got != `{"login":"octocat"}` {
+		t.Errorf("Body = %q", got)
+	}
+
+	// The body may itself contain blank lines
+	resp, err = parseGHResponse([]byte("HTTP/1.1 404 Not Found\n\n{\n\n}"))
+	if err != nil || resp.Status != http.StatusNotFound || string(resp.Body) != "{\n\n}" {
+		t.Errorf("parseGHResponse of a 404 = %+v, %v", resp, err)
+	}
+
+	for _, out := range []string{
+		"",
+		"no headers at all",
+		"{\"message\":\"Not Found\"}\n\n",
+		"HTTP/2.0\n\n{}",
+		"HTTP/2.0 OK\n\n{}",
+	} {
+		if _, err := parseGHResponse([]byte(out)); err == nil {
+			t.Errorf("parseGHResponse(%q) succeeded", out)
+		}
+	}
+}
+
+func TestRateLimitReset(t *testing.T) {
+	tests := []struct {
+		remaining, reset string
+		want             time.Time
+		ok               bool
+	}{
+		{"0", "1700000000", time.Unix(1700000000, 0), true},
+		{"12", "1700000000", time.Time{}, false}, // Quota left
+		{"", "1700000000", time.Time{}, false},
+		{"0", "", time.Time{}, false},
+		{"0", "soon", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		header.Set("X-RateLimit-Remaining", tt.remaining)
+		header.Set("X-RateLimit-Reset", tt.reset)
+		got, ok := rateLimitReset(header)
+		if !got.Equal(tt.want) || ok != tt.ok {
+			t.Errorf("rateLimitReset(remaining %q, reset %q) = %v, %v, want %v, %v", tt.remaining, tt.reset, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	const backoff = 2 * time.Second
+	resetIn := func(d time.Duration) http.Header {
+		header := http.Header{}
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(d).Unix(), 10))
+		return header
+	}
+	retryAfter := func(secs string) http.Header {
+		header := http.Header{}
+		header.Set("Retry-After", secs)
+		return header
+	}
+
+	tests := []struct {
+		name     string
+		resp     ghResponse
+		min, max time.Duration
+		retry    bool
+	}{
+		{"Retry-After", ghResponse{Status: 403, Header: retryAfter("30")}, 30 * time.Second, 30 * time.Second, true},
+		{"Retry-After on 429", ghResponse{Status: 429, Header: retryAfter("5")}, 5 * time.Second, 5 * time.Second, true},
+		{"Retry-After too long", ghResponse{Status: 403, Header: retryAfter("3600")}, time.Hour, time.Hour, false},
+		{"reset soon", ghResponse{Status: 403, Header: resetIn(20 * time.Second)}, 19 * time.Second, 22 * time.Second, true},
+		{"reset passed", ghResponse{Status: 429, Header: resetIn(-time.Minute)}, time.Second, time.Second, true},
+		{"reset too late", ghResponse{Status: 403, Header: resetIn(time.Hour)}, 59 * time.Minute, 61 * time.Minute, false},
+		{"secondary limit", ghResponse{Status: 403, Header: http.Header{}, Body: []byte(`{"message":"You have exceeded a secondary rate limit"}`)}, ghMaxRateLimitWait, ghMaxRateLimitWait, true},
+		{"forbidden", ghResponse{Status: 403, Header: http.Header{}, Body: []byte(`{"message":"Resource not accessible by integration"}`)}, 0, 0, false},
+		{"server error", ghResponse{Status: 502, Header: http.Header{}}, backoff, backoff, true},
+		{"not found", ghResponse{Status: 404, Header: http.Header{}}, 0, 0, false},
+		{"unprocessable", ghResponse{Status: 422, Header: http.Header{}}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		wait, retry := retryDelay(&tt.resp, backoff)
+		if retry != tt.retry || wait < tt.min || wait > tt.max {
+			t.Errorf("%s: retryDelay = %v, %v, want %v to %v, %v", tt.name, wait, retry, tt.min, tt.max, tt.retry)
+		}
+	}
+}
+
+func TestIsTransientGHError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"error connecting to api.github.com\ncheck your internet connection or https://githubstatus.com", true},
+		{"Post \"https://api.github.com/graphql\": dial tcp: lookup api.github.com: no such host", true},
+		{"HTTP 502: Bad Gateway", true},
+		{"API rate limit exceeded for user ID 1", true},
+		{"unknown flag: --bogus", false},
+		{"HTTP 404: Not Found (https://api.github.com/repos/acme/nope)", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isTransientGHError(tt.stderr); got != tt.want {
+			t.Errorf("isTransientGHError(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// maxBranchColumnWidth caps the branch column of the list.
+const maxBranchColumnWidth = 24
+
+// localStatus is the working tree state of a checkout.
+type localStatus struct {
+	Branch    string // Checked-out branch, "" for a detached HEAD
+	Dirty     bool   // Uncommitted changes or untracked files
+	Modified  bool   // Uncommitted changes to tracked files
+	Untracked bool   // Untracked files
+	Upstream  bool   // The branch tracks an upstream, so Ahead and Behind are known
+	Ahead     int    // Commits not pushed to the upstream
+	Behind    int    // Upstream commits not pulled
+	Stashes   int
+	Err       error // Why the repo couldn't be read; the rest is unset
+}
+
+type localStatusLoadedMsg struct {
+	statuses map[string]localStatus // Keyed by repo directory
+	complete bool                   // Every repo was read, not just the visible rows
+	err      error                  // First failure, if any repo couldn't be read
+}
+
+// loadLocalStatusCmd reads the working tree state of the given repos in the
+// background, a few repos at a time like the release scan. complete says
+// whether they're all the repos or just the rows on screen.
+func loadLocalStatusCmd(repos []GitRepo, complete bool) tea.Cmd {
+	return func() tea.Msg {
+		statuses := make(map[string]localStatus, len(repos))
+		var firstErr error
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, releaseScanConcurrency)
+
+		for _, repo := range repos {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(dir string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				status, err := getLocalStatus(dir)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("%s: %w", dir, err)
+					}
+					status = localStatus{Err: err}
+				}
+				statuses[dir] = status
+			}(repo.Directory)
+		}
+		wg.Wait()
+
+		return localStatusLoadedMsg{statuses: statuses, complete: complete, err: firstErr}
+	}
+}
+
+// startLocalStatusLoad marks the working tree state as loading when the
+// search needs it for every repo and it hasn't been read yet, reporting
+// whether the caller should run loadLocalStatusCmd.
+func (m *model) startLocalStatusLoad() bool {
+	if !m.query.usesLocalStatus() || m.localStatusComplete || m.loadingLocalStatus {
+		return false
+	}
+	m.loadingLocalStatus = true
+	m.startJob(jobLocalStatus)
+	return true
+}
+
+// loadVisibleLocalStatus reads the working tree state of the rows on screen
+// that haven't been read yet, when the status columns are shown.
+func (m *model) loadVisibleLocalStatus() tea.Cmd {
+	if !m.showStatusColumns || m.currentView != listView || m.loadingLocalStatus {
+		return nil
+	}
+	// Same rows as renderListView shows
+	visibleHeight := m.listVisibleHeight()
+	var repos []GitRepo
+	end := min(m.scrollOffset+visibleHeight, len(m.filteredRepos))
+	for i := m.scrollOffset; i < end; i++ {
+		repo := m.filteredRepos[i]
+		if repo.RemoteOnly || m.localStatusPending[repo.Directory] {
+			continue
+		}
+		if _, ok := m.localStatuses[repo.Directory]; ok {
+			continue
+		}
+		repos = append(repos, repo)
+	}
+	if len(repos) == 0 {
+		return nil
+	}
+
+	if m.localStatusPending == nil {
+		m.localStatusPending = make(map[string]bool)
+	}
+	for _, repo := range repos {
+		m.localStatusPending[repo.Directory] = true
+	}
+	m.startJob(jobLocalStatus)
+	return loadLocalStatusCmd(repos, false)
+}
+
+// reloadLocalStatus rereads working tree state that may have changed:
+// every repo if they've all been read, otherwise the rows on screen, which
+// Update picks up once the old state is dropped.
+func (m *model) reloadLocalStatus() tea.Cmd {
+	if m.loadingLocalStatus {
+		return nil
+	}
+	if m.localStatusComplete {
+		m.loadingLocalStatus = true
+		m.startJob(jobLocalStatus)
+		return loadLocalStatusCmd(m.repos, true)
+	}
+	m.localStatuses = nil
+	m.localStatusPending = nil
+	return nil
+}
+
+// mergeLocalStatuses records freshly read working tree state, including
+// repos that couldn't be read, so they aren't retried until the next reload.
+func (m *model) mergeLocalStatuses(statuses map[string]localStatus) {
+	if m.localStatuses == nil {
+		m.localStatuses = make(map[string]localStatus, len(statuses))
+	}
+	for dir, status := range statuses {
+		m.localStatuses[dir] = status
+		delete(m.localStatusPending, dir)
+	}
+}
+
+// getLocalStatus reads the branch, changes, upstream divergence and stashes
+// with a single porcelain git status. It takes no optional locks, so it
+// never refreshes the index under a git command the user is running.
+func getLocalStatus(repoDir string) (localStatus, error) {
+	cmd := exec.Command("git", "--no-optional-locks", "-C", repoDir, "status", "--porcelain=v2", "--branch", "--show-stash")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return localStatus{}, fmt.Errorf("%s", ghErrorText(err, stderr.String()))
+	}
+
+	var status localStatus
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if head, found := strings.CutPrefix(line, "# branch.head "); found {
+			if head != "(detached)" {
+				status.Branch = head
+			}
+		} else if ab, found := strings.CutPrefix(line, "# branch.ab "); found {
+			// # branch.ab +<ahead> -<behind>
+			var ahead, behind int
+			if _, err := fmt.Sscanf(ab, "+%d -%d", &ahead, &behind); err == nil {
+				status.Upstream = true
+				status.Ahead, status.Behind = ahead, behind
+			}
+		} else if stashes, found := strings.CutPrefix(line, "# stash "); found {
+			status.Stashes, _ = strconv.Atoi(stashes)
+		} else if strings.HasPrefix(line, "? ") {
+			status.Untracked = true
+		} else if !strings.HasPrefix(line, "#") && line != "" {
+			status.Modified = true
+		}
+	}
+	status.Dirty = status.Modified || status.Untracked
+	return status, nil
+}
+
+// branchColumnWidth is the width of the branch column for the rows on
+// screen.
+func (m model) branchColumnWidth(start, end int) int {
+	width := 0
+	for i := start; i < end; i++ {
+		if status, ok := m.localStatuses[m.filteredRepos[i].Directory]; ok && status.Err == nil {
+			width = max(width, uniseg.StringWidth(status.Branch))
+		}
+	}
+	return min(max(width, len("(detached)")), maxBranchColumnWidth)
+}
+
+// renderStatusColumns shows a repo's branch and, in a fixed-width column,
+// * for changes, ? for untracked files, ↑/↓ for commits ahead of and behind
+// the upstream and ≡ for stashes.
+func (m model) renderStatusColumns(repo GitRepo, branchWidth int, selected bool) string {
+	const stateWidth = 16
+
+	branchStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("6"))
+
+	dirtyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	syncStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("13"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	if selected {
+		// Keep the selected row's colors readable
+		selectedStyle := lipgloss.NewStyle().
+			Background(lipgloss.Color("62")).
+			Foreground(lipgloss.Color("230"))
+		branchStyle, dirtyStyle, syncStyle, dimStyle = selectedStyle, selectedStyle, selectedStyle, selectedStyle
+	}
+
+	status, ok := m.localStatuses[repo.Directory]
+	if !ok || status.Err != nil {
+		label := ""
+		if ok {
+			label = "unreadable"
+		} else if !repo.RemoteOnly && m.localStatusPending[repo.Directory] {
+			label = "…"
+		}
+		return "  " + dimStyle.Render(fmt.Sprintf("%-*s", branchWidth+1+stateWidth, label))
+	}
+
+	branch := status.Branch
+	style := branchStyle
+	if branch == "" {
+		branch, style = "(detached)", dimStyle
+	}
+	if uniseg.StringWidth(branch) > branchWidth {
+		branch = clipGraphemes(branch, branchWidth-1) + "…"
+	}
+	branch += strings.Repeat(" ", max(branchWidth-uniseg.StringWidth(branch), 0))
+
+	var parts []string
+	width := 0
+	add := func(text string, style lipgloss.Style) {
+		parts = append(parts, style.Render(text))
+		width += uniseg.StringWidth(text) + 1
+	}
+	if status.Modified {
+		add("*", dirtyStyle)
+	}
+	if status.Untracked {
+		add("?", dirtyStyle)
+	}
+	if status.Ahead > 0 {
+		add(fmt.Sprintf("↑%d", status.Ahead), syncStyle)
+	}
+	if status.Behind > 0 {
+		add(fmt.Sprintf("↓%d", status.Behind), syncStyle)
+	}
+	if status.Stashes > 0 {
+		add(fmt.Sprintf("≡%d", status.Stashes), dimStyle)
+	}
+	state := strings.Join(parts, dimStyle.Render(" "))
+	padding := dimStyle.Render(strings.Repeat(" ", max(stateWidth-max(width-1, 0), 0)))
+
+	return "  " + style.Render(branch) + dimStyle.Render(" ") + state + padding
+}
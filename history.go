@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const historyFile = "history.json"
+
+// historyMaxRank caps the sum of all ranks, like zoxide's _ZO_MAXAGE. Past
+// it every rank decays, and entries that drop below 1 are forgotten.
+const historyMaxRank = 10000
+
+// frecencyWeight scales a repo's frecency into a bonus on its match score.
+// The bonus grows with the log of the frecency so that history breaks ties
+// and lifts favourites without burying a much better match.
+const frecencyWeight = 16
+
+// historyEntry records how often and how recently a repo was selected.
+type historyEntry struct {
+	Rank         float64   `json:"rank"`
+	LastSelected time.Time `json:"lastSelected"`
+}
+
+// selectionHistory holds the repos opened with Enter or cd'd into with
+// Ctrl+D, keyed by directory.
+type selectionHistory map[string]historyEntry
+
+type historySavedMsg struct {
+	err error
+}
+
+func loadHistory() (selectionHistory, error) {
+	history := make(selectionHistory)
+	path, err := dataPath(historyFile)
+	if err != nil {
+		return history, err
+	}
+	if err := readJSONFile(path, &history); err != nil {
+		return make(selectionHistory), fmt.Errorf("%s: %w", path, err)
+	}
+	if history == nil {
+		// The file held null
+		history = make(selectionHistory)
+	}
+	return history, nil
+}
+
+func saveHistory(history selectionHistory) error {
+	path, err := dataPath(historyFile)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, history)
+}
+
+// visit records a selection of dir, aging every entry once the ranks add up
+// to more than historyMaxRank.
+func (h selectionHistory) visit(dir string, now time.Time) {
+	entry := h[dir]
+	entry.Rank++
+	entry.LastSelected = now
+	h[dir] = entry
+
+	total := 0.0
+	for _, entry := range h {
+		total += entry.Rank
+	}
+	if total <= historyMaxRank {
+		return
+	}
+	factor := 0.9 * historyMaxRank / total
+	for dir, entry := range h {
+		entry.Rank *= factor
+		if entry.Rank < 1 {
+			delete(h, dir)
+		} else {
+			h[dir] = entry
+		}
+	}
+}
+
+// frecency is zoxide's score: the rank, weighted by how recently the repo
+// was last selected.
+func (h selectionHistory) frecency(dir string, now time.Time) float64 {
+	entry, ok := h[dir]
+	if !ok {
+		return 0
+	}
+	age := now.Sub(entry.LastSelected)
+	switch {
+	case age < time.Hour:
+		return entry.Rank * 4
+	case age < 24*time.Hour:
+		return entry.Rank * 2
+	case age < 7*24*time.Hour:
+		return entry.Rank / 2
+	}
+	return entry.Rank / 4
+}
+
+// frecencyBonus is what a repo's frecency adds to its match score.
+func (h selectionHistory) frecencyBonus(dir string, now time.Time) int {
+	return int(math.Round(frecencyWeight * math.Log1p(h.frecency(dir, now))))
+}
+
+// recordSelection counts a selection of dir towards its frecency, and the
+// search that found it towards the search history, right away and returns
+// the command that saves them.
+func (m *model) recordSelection(dir string) tea.Cmd {
+	now := time.Now()
+	if m.history == nil {
+		m.history = make(selectionHistory)
+	}
+	m.history.visit(dir, now)
+
+	prMode, keepSearch := m.searchHistoryMode()
+	search := pastSearch{Query: m.searchInput.value(), Regex: m.regexMode}
+	if keepSearch && search.Query != "" {
+		if m.searchHistory == nil {
+			m.searchHistory = &searchHistory{}
+		}
+		m.searchHistory.add(prMode, search)
+	} else {
+		search = pastSearch{}
+	}
+	m.recall = nil
+	return recordSelectionCmd(dir, search, prMode, now)
+}
+
+// recordSelectionCmd adds the selection, and the search if any, to the
+// histories on disk. It rereads the files rather than saving the model's
+// copies, so that selections made in other qgh sessions in the meantime
+// aren't lost.
+func recordSelectionCmd(dir string, search pastSearch, prMode bool, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		history, err := loadHistory()
+		if err != nil {
+			return historySavedMsg{err: err}
+		}
+		history.visit(dir, now)
+		if err := saveHistory(history); err != nil {
+			return historySavedMsg{err: err}
+		}
+
+		if search.Query == "" {
+			return historySavedMsg{}
+		}
+		searches, err := loadSearchHistory()
+		if err != nil {
+			return historySavedMsg{err: err}
+		}
+		searches.add(prMode, search)
+		return historySavedMsg{err: saveSearchHistory(searches)}
+	}
+}
+
+// runHistoryCommand implements `qgh history`, which lists the history best
+// first, and its forget and prune subcommands.
+func runHistoryCommand(args []string) error {
+	history, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		now := time.Now()
+		dirs := make([]string, 0, len(history))
+		for dir := range history {
+			dirs = append(dirs, dir)
+		}
+		sort.Slice(dirs, func(i, j int) bool {
+			fi, fj := history.frecency(dirs[i], now), history.frecency(dirs[j], now)
+			if fi != fj {
+				return fi > fj
+			}
+			return dirs[i] < dirs[j]
+		})
+		for _, dir := range dirs {
+			fmt.Printf("%8.1f  %s\n", history.frecency(dir, now), dir)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "forget":
+		// Forget the given repos, or the current directory
+		dirs := args[1:]
+		if len(dirs) == 0 {
+			dirs = []string{"."}
+		}
+		var absDirs []string
+		for _, dir := range dirs {
+			absDir, err := filepath.Abs(dir)
+			if err != nil {
+				return err
+			}
+			if _, ok := history[absDir]; !ok {
+				return fmt.Errorf("%s is not in the history", absDir)
+			}
+			absDirs = append(absDirs, absDir)
+		}
+		for _, dir := range absDirs {
+			delete(history, dir)
+			fmt.Printf("Forgot %s\n", dir)
+		}
+	case "prune":
+		// Drop repos that have been deleted or moved
+		pruned := 0
+		for dir := range history {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				delete(history, dir)
+				pruned++
+			}
+		}
+		fmt.Printf("Pruned %d entries\n", pruned)
+	default:
+		return fmt.Errorf("unknown history command %q (want forget or prune)", args[0])
+	}
+	return saveHistory(history)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Issue struct {
+	Number         int
+	Title          string
+	URL            string
+	RepoURL        string // GitHub repository URL this issue belongs to
+	Role           string // "assigned", "created" or "assigned, created"
+	MatchPositions []int  // Rune offsets in Title matched by the search, for highlighting
+}
+
+// IssueCache holds the open issues assigned to or created by the user,
+// mirroring PRCache.
+type IssueCache struct {
+	allIssues    []Issue
+	issuesByRepo map[string][]Issue // Maps GitHub repo URL to list of issues
+	loaded       bool
+	omitted      int // Issues the search found beyond the ones fetched
+}
+
+// GitHub's search API returns at most 1,000 results, 100 a page.
+const (
+	issuePageSize = 100
+	issueMaxPages = 10
+)
+
+// issueSearchItem is one result of the issue search.
+type issueSearchItem struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	URL           string `json:"html_url"`
+	RepositoryURL string `json:"repository_url"`
+}
+
+type issueCacheLoadedMsg struct {
+	cache *IssueCache
+	err   error
+}
+
+func loadIssueCacheCmd(gh *ghSession) tea.Cmd {
+	return func() tea.Msg {
+		cache, err := loadAllUserIssues(gh)
+		return issueCacheLoadedMsg{cache: cache, err: err}
+	}
+}
+
+func loadAllUserIssues(gh *ghSession) (*IssueCache, error) {
+	if err := gh.authError(); err != nil {
+		return nil, err
+	}
+
+	var allIssues []Issue
+	omitted := 0
+	indexByURL := make(map[string]int)
+
+	for _, search := range []struct {
+		role      string
+		qualifier string
+	}{
+		{"assigned", "assignee"},
+		{"created", "author"},
+	} {
+		query := fmt.Sprintf("is:issue is:open %s:%s", search.qualifier, gh.Login)
+		items, total, err := searchIssues(gh, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %s issues: %w", search.role, err)
+		}
+		omitted += max(total-len(items), 0)
+
+		for _, item := range items {
+			// Issues both assigned to and created by the user show up twice
+			if i, seen := indexByURL[item.URL]; seen {
+				allIssues[i].Role += ", " + search.role
+				continue
+			}
+
+			_, nameWithOwner, found := strings.Cut(item.RepositoryURL, "/repos/")
+			if !found {
+				continue
+			}
+
+			indexByURL[item.URL] = len(allIssues)
+			allIssues = append(allIssues, Issue{
+				Number:  item.Number,
+				Title:   item.Title,
+				URL:     item.URL,
+				RepoURL: githubRepoURL(nameWithOwner),
+				Role:    search.role,
+			})
+		}
+	}
+
+	issuesByRepo := make(map[string][]Issue)
+	for _, issue := range allIssues {
+		issuesByRepo[issue.RepoURL] = append(issuesByRepo[issue.RepoURL], issue)
+	}
+
+	return &IssueCache{
+		allIssues:    allIssues,
+		issuesByRepo: issuesByRepo,
+		loaded:       true,
+		omitted:      omitted,
+	}, nil
+}
+
+// searchIssues fetches every page of an issue search, up to the search
+// API's limit, returning the results and how many GitHub found in all.
+func searchIssues(gh *ghSession, query string) ([]issueSearchItem, int, error) {
+	var items []issueSearchItem
+	total := 0
+	for page := 1; page <= issueMaxPages; page++ {
+		output, err := gh.api("-X", "GET", "search/issues", "-f", "q="+query,
+			"-f", fmt.Sprintf("per_page=%d", issuePageSize), "-f", fmt.Sprintf("page=%d", page))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var results struct {
+			TotalCount int               `json:"total_count"`
+			Items      []issueSearchItem `json:"items"`
+		}
+		if err := json.Unmarshal(output, &results); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse issue search results: %w", err)
+		}
+		items = append(items, results.Items...)
+		total = results.TotalCount
+		if len(results.Items) < issuePageSize || len(items) >= total {
+			break
+		}
+	}
+	return items, total, nil
+}
+
+// filterReposByIssues is the issues mode counterpart of filterReposByPRs.
+// Unlike PR mode, an empty search lists every repo with issues.
+func (m *model) filterReposByIssues() {
+	if m.issueCache == nil || !m.issueCache.loaded {
+		// If cache not loaded yet, show no repos
+		m.filteredRepos = []GitRepo{}
+		return
+	}
+
+	type scoredIssue struct {
+		issue Issue
+		score int
+	}
+	var matchingIssues []scoredIssue
+	for _, issue := range m.issueCache.allIssues {
+		if score, positions, ok := m.query.matchText(issue.Title); ok {
+			issue.MatchPositions = positions
+			matchingIssues = append(matchingIssues, scoredIssue{issue: issue, score: score})
+		}
+	}
+
+	// Best matching issues first; a repo ranks by its best issue
+	sort.SliceStable(matchingIssues, func(i, j int) bool {
+		return matchingIssues[i].score > matchingIssues[j].score
+	})
+	issuesByRepo := make(map[string][]Issue)
+	repoScores := make(map[string]int)
+	for _, match := range matchingIssues {
+		if _, seen := issuesByRepo[match.issue.RepoURL]; !seen {
+			repoScores[match.issue.RepoURL] = match.score
+		}
+		issuesByRepo[match.issue.RepoURL] = append(issuesByRepo[match.issue.RepoURL], match.issue)
+	}
+
+	var matches []scoredRepo
+	for _, repo := range m.repos {
+		if repo.GitHubURL == "N/A" || repo.GitHubURL == "Non-GitHub" {
+			continue
+		}
+		if matchingIssues, exists := issuesByRepo[repo.GitHubURL]; exists {
+			repoWithIssues := repo
+			repoWithIssues.MatchingPRs = nil
+			repoWithIssues.MatchingIssues = matchingIssues
+			if m.prCache != nil && m.prCache.loaded {
+				repoWithIssues.PRCount = len(m.prCache.prsByRepo[repo.GitHubURL])
+			}
+			if m.query.matchesFields(repoWithIssues, m.cachedPRs(repo.GitHubURL), m.localStatuses) {
+				matches = append(matches, scoredRepo{repo: repoWithIssues, score: repoScores[repo.GitHubURL]})
+			}
+		}
+	}
+
+	m.filteredRepos = rankRepos(matches, m.history)
+}
+
+// enterIssueMode switches the list to issues mode, loading the issue cache
+// the first time it's needed.
+func (m model) enterIssueMode() (model, tea.Cmd) {
+	m.issueMode = true
+	m.prMode = false
+	m.remoteMode = false
+	m.searchInput.setValue("")
+	m.filterRepos()
+
+	if m.issueCache == nil && m.gh != nil {
+		if !m.gh.authenticated() {
+			m.issueCache = &IssueCache{issuesByRepo: make(map[string][]Issue), loaded: true}
+			return m, nil
+		}
+		m.issueCache = &IssueCache{} // Placeholder so repeated toggles don't reload
+		m.startJob(jobIssues)
+		return m, loadIssueCacheCmd(m.gh)
+	}
+	return m, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -10,71 +11,191 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-isatty"
+	"github.com/rivo/uniseg"
 )
 
 type GitRepo struct {
-	Directory string
-	Origin    string
-	GitHubURL string
-	PRCount   int
-	MatchingPRs []PR // Used in PR mode to store matching PRs for this repo
+	Directory      string
+	Origin         string
+	GitHubURL      string
+	PRCount        int
+	MatchingPRs    []PR          // Used in PR mode to store matching PRs for this repo
+	MatchingIssues []Issue       // Used in issues mode to store matching issues for this repo
+	Meta           *RepoMetadata // Remote repository metadata, nil until fetched
+	RemoteOnly     bool          // Org repo listed in remote mode that isn't cloned yet; Directory is where it would go
+	OriginErr      error         // Why origin couldn't be read when Origin is "N/A" for a reason other than there being none
+	MatchPositions []int         // Rune offsets in Directory matched by the search, for highlighting
 }
 
 type PR struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	URL    string `json:"url"`
-	Branch string `json:"headRefName"`
-	RepoURL string // GitHub repository URL this PR belongs to
+	Number         int      `json:"number"`
+	Title          string   `json:"title"`
+	URL            string   `json:"url"`
+	Branch         string   `json:"headRefName"`
+	Labels         []string `json:"labels,omitempty"`
+	Body           string   `json:"body,omitempty"`
+	RepoURL        string   // GitHub repository URL this PR belongs to
+	MatchPositions []int    `json:"-"` // Rune offsets in Title matched by the search, for highlighting
+	MatchNotes     []string `json:"-"` // Fields other than the title the search matched, e.g. "label bug"
 }
 
 // Global PR cache
 type PRCache struct {
-	allPRs []PR
+	allPRs    []PR
 	prsByRepo map[string][]PR // Maps GitHub repo URL to list of PRs
-	loaded bool
+	loaded    bool
+
+	// Who the PRs were fetched for and when, so a persisted cache can be
+	// shown immediately and revalidated in the background
+	login     string
+	host      string
+	fetchedAt time.Time
 }
 
 type viewState int
 
+type detailSection int
+
+const (
+	detailSectionPRs      detailSection = iota // PRs, or issues in issues mode
+	detailSectionRuns                          // GitHub Actions workflow runs
+	detailSectionReleases                      // Latest release, tags and unreleased commits
+	detailSectionCount
+)
+
 const (
 	listView viewState = iota
 	detailView
+	notificationsView
+	savedSearchesView
+	syncView
+	execView
 )
 
 type model struct {
-	repos        []GitRepo
-	filteredRepos []GitRepo
-	searchInput  string
-	cursor       int
-	minPaths     []string
-	prCache      *PRCache // Cache of all user PRs
+	repos             []GitRepo
+	filteredRepos     []GitRepo
+	searchInput       textInput
+	query             searchQuery // searchInput parsed, as of queryInput
+	queryInput        string
+	queryRegex        bool  // regexMode as of queryInput
+	regexMode         bool  // The search is an RE2 pattern rather than a query
+	queryErr          error // Why searchInput doesn't parse, shown in the search box
+	cursor            int
+	minPaths          []string
+	prCache           *PRCache   // Cache of all user PRs
+	prCacheRefreshing bool       // True while the PR cache is being revalidated
+	gh                *ghSession // GitHub identity, nil until established
+
+	// Status bar state
+	jobs      map[string]int // Background jobs in flight, by label
+	lastErr   *statusError   // Last failure, until replaced or the job succeeds
+	notice    string         // Transient confirmation, cleared after noticeDuration
+	noticeSeq int            // Identifies the notice a clearNoticeMsg is for
 	
 	// Detail view state
-	currentView    viewState
-	selectedRepo   *GitRepo
-	repoDetails    []PR
-	detailCursor   int
-	loadingPRs     bool
-	prLoadError    string
+	currentView   viewState
+	selectedRepo  *GitRepo
+	repoDetails   []PR
+	detailCursor  int
+	loadingPRs    bool
+	prLoadError   string
+	detailSection detailSection // Which tab of the detail view is shown
+
+	// Workflow runs of the selected repo, loaded when the runs tab opens
+	workflowRuns  []WorkflowRun
+	runsRepoURL   string // Repo the loaded runs belong to
+	loadingRuns   bool
+	runsLoadError string
 	
+	// Release state of the selected repo, loaded when the releases tab opens
+	releaseInfo          *ReleaseInfo
+	releaseRepoURL       string // Repo the loaded release info belongs to
+	loadingRelease       bool
+	releaseLoadError     string
+	releaseStatuses      map[string]releaseStatus // Unreleased commits column, keyed by directory
+	releaseStatusPending map[string]bool          // Rows being scanned for the column
+
+	// Working tree state, read for every repo the first time a search uses
+	// dirty: or branch:, and for the rows on screen when the status columns
+	// are shown
+	localStatuses       map[string]localStatus // Keyed by directory
+	localStatusPending  map[string]bool        // Rows being read; failures land in localStatuses with Err set
+	localStatusComplete bool                   // Every repo has been read
+	loadingLocalStatus  bool                   // Reading every repo
+	showStatusColumns   bool
+
+	// Repos selected in this and earlier sessions, for frecency ranking, and
+	// the searches that found them, for recall
+	history       selectionHistory
+	searchHistory *searchHistory
+	recall        *searchRecall // Set while cycling through past searches
+
 	// Navigation state
 	startedInDetailView bool // True if we opened directly in detail view
 	
 	// Terminal/scrolling state
-	terminalHeight int
-	scrollOffset   int
+	terminalHeight     int
+	terminalWidth      int
+	scrollOffset       int
 	detailScrollOffset int
 	
 	// PR mode state
 	prMode bool // True if in PR search mode
+
+	// Issues mode state
+	issueMode  bool        // True if in issues search mode
+	issueCache *IssueCache // Cache of issues assigned to or created by the user
+	repoIssues []Issue     // Issues shown in the detail view in issues mode
+
+	// Remote mode state: searching the configured orgs' repositories
+	remoteMode    bool
+	remoteRepos   []remoteRepo // nil until listed
+	remoteLoading bool
+	remoteErr     error
+	cloning       map[string]bool   // GitHub URLs being cloned
+	cloneErrors   map[string]string // GitHub URL to the last clone error
+
+	config        *Config
+	workspaceRoot string // Directory remote repos are cloned into
+
+	// Notifications inbox state
+	previousView             viewState // View to return to when leaving the inbox
+	notifications            []Notification
+	notificationsLoading     bool
+	notificationsErr         error
+	notificationsTruncated   bool // Only the first pages of the inbox were read
+	notificationCursor       int
+	notificationScrollOffset int
+
+	// Saved searches picker
+	savedSearchCursor       int
+	savedSearchScrollOffset int
+
+	// Repos marked with Tab for sync and exec, keyed by directory, and a
+	// bulk action waiting for a yes
+	marked  map[string]bool
+	confirm *confirmation
+
+	// The last fetch and fast-forward across repos, while and after it runs
+	sync *syncRun
+
+	// Running a shell command across repos: the prompt, then the last run
+	execInput     textInput
+	execPrompting bool
+	exec          *execRun
 }
 
 type prLoadedMsg struct {
@@ -91,16 +212,20 @@ type changeDirMsg struct {
 	path string
 }
 
-func loadPRsCmd(repoURL string) tea.Cmd {
+func loadPRsCmd(gh *ghSession, repoURL string) tea.Cmd {
 	return func() tea.Msg {
-		prs, err := getRepositoryPRs(repoURL)
+		prs, err := getRepositoryPRs(gh, repoURL)
 		return prLoadedMsg{prs: prs, err: err}
 	}
 }
 
-func loadPRCacheCmd() tea.Cmd {
+func loadPRCacheCmd(gh *ghSession) tea.Cmd {
 	return func() tea.Msg {
-		cache, err := loadAllUserPRs()
+		cache, err := loadAllUserPRs(gh)
+		if err == nil {
+			// Persist for the next launch; failing to save isn't worth surfacing
+			_ = savePRCache(cache)
+		}
 		return prCacheLoadedMsg{cache: cache, err: err}
 	}
 }
@@ -112,40 +237,316 @@ func changeDirCmd(path string) tea.Cmd {
 }
 
 func (m model) Init() tea.Cmd {
-	// Only load PR cache if we're in PR mode or not in single repo detail view
-	if !m.startedInDetailView && (m.prCache == nil || !m.prCache.loaded) {
-		return loadPRCacheCmd()
+	// Establish the GitHub session once; fetches are started when it arrives.
+	// A persisted PR cache is already on screen and is revalidated then.
+	if !m.startedInDetailView {
+		cmds := []tea.Cmd{connectGitHubCmd()}
+		if interval := m.config.refreshEvery(); interval > 0 {
+			cmds = append(cmds, refreshTickCmd(interval))
+		}
+		if m.loadingLocalStatus {
+			// The initial search filters on working tree state
+			cmds = append(cmds, loadLocalStatusCmd(m.repos, true))
+		}
+		return tea.Batch(cmds...)
 	}
 	
 	if m.currentView == detailView && m.selectedRepo != nil && m.loadingPRs {
-		return loadPRsCmd(m.selectedRepo.GitHubURL)
+		return connectGitHubCmd()
 	}
 	return nil
 }
 
+// Update handles a message, then starts scanning the release and working
+// tree state of any rows it brought on screen.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.handleMsg(msg)
+	next, ok := updated.(model)
+	if !ok {
+		return updated, cmd
+	}
+	releases := next.loadVisibleReleaseStatus()
+	local := next.loadVisibleLocalStatus()
+	return next, tea.Batch(cmd, releases, local)
+}
+
+func (m model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.terminalHeight = msg.Height
+		m.terminalWidth = msg.Width
 		return m, nil
 		
+	case clearNoticeMsg:
+		// Only clear the notice this tick was scheduled for
+		if msg.seq == m.noticeSeq {
+			m.notice = ""
+		}
+		return m, nil
+
+	case pathCopiedMsg:
+		if msg.err != nil {
+			m.reportError("copying path", msg.err)
+			return m, nil
+		}
+		return m, m.notify("Copied path")
+
+	case historySavedMsg:
+		if msg.err != nil {
+			m.reportError("saving history", msg.err)
+		}
+		return m, nil
+
+	case ghSessionMsg:
+		m.gh = msg.session
+		m.finishJob(jobConnect, m.gh.authError())
+		if !m.gh.authenticated() {
+			// Nothing to fetch; the views render the unauthenticated state
+			if m.loadingPRs {
+				m.finishJob(jobRepoPRs, nil)
+			}
+			if m.notificationsLoading {
+				m.finishJob(jobNotifications, nil)
+				m.notificationsErr = m.gh.authError()
+			}
+			if m.prCache == nil || m.prCache.host != m.gh.Host {
+				m.prCache = &PRCache{
+					allPRs:    []PR{},
+					prsByRepo: make(map[string][]PR),
+					loaded:    true,
+				}
+			}
+			// Otherwise the persisted cache stays, marked as unauthenticated
+			if m.issueMode {
+				m.issueCache = &IssueCache{issuesByRepo: make(map[string][]Issue), loaded: true}
+			}
+			m.loadingPRs = false
+			m.notificationsLoading = false
+			m.filterRepos()
+			return m, nil
+		}
+		var cmds []tea.Cmd
+		if m.remoteMode && m.remoteRepos == nil && len(m.config.Orgs) > 0 {
+			m.remoteLoading = true
+			m.startJob(jobRemoteRepos)
+			cmds = append(cmds, loadRemoteReposCmd(m.gh, m.config.Orgs))
+		}
+		if m.notificationsLoading {
+			// The inbox was opened before the session was ready
+			cmds = append(cmds, loadNotificationsCmd(m.gh))
+		}
+		if m.issueMode && m.issueCache == nil {
+			m.issueCache = &IssueCache{}
+			m.startJob(jobIssues)
+			cmds = append(cmds, loadIssueCacheCmd(m.gh))
+		}
+		if m.prCache != nil && !m.prCache.belongsTo(m.gh) {
+			// Persisted for a different account; don't show someone else's PRs
+			m.prCache = nil
+			m.filterRepos()
+		}
+		// Only load PR cache if we're in PR mode or not in single repo detail view
+		if !m.startedInDetailView {
+			if m.prCache == nil || m.prCache.stale() {
+				m.prCacheRefreshing = true
+				m.startJob(jobPRCache)
+				cmds = append(cmds, loadPRCacheCmd(m.gh))
+			}
+			m.startJob(jobMetadata)
+			cmds = append(cmds, loadRepoMetadataCmd(m.gh, m.repos))
+		} else if m.currentView == detailView && m.selectedRepo != nil && m.loadingPRs {
+			cmds = append(cmds, loadPRsCmd(m.gh, m.selectedRepo.GitHubURL))
+		}
+		var sectionCmd tea.Cmd
+		m, sectionCmd = m.loadDetailSection()
+		cmds = append(cmds, sectionCmd)
+		return m, tea.Batch(cmds...)
+
+	case refreshTickMsg:
+		var cmd tea.Cmd
+		m, cmd = m.refresh()
+		return m, tea.Batch(cmd, refreshTickCmd(m.config.refreshEvery()))
+
 	case prCacheLoadedMsg:
+		m.prCacheRefreshing = false
+		m.finishJob(jobPRCache, msg.err)
 		if msg.err != nil {
-			// If cache loading fails, create empty cache
-			m.prCache = &PRCache{
-				allPRs: []PR{},
-				prsByRepo: make(map[string][]PR),
-				loaded: true,
+			// Keep whatever we already have; only fall back to an empty cache
+			// so the list isn't stuck on "Loading PR cache..."
+			if m.prCache == nil || !m.prCache.loaded {
+				m.prCache = &PRCache{
+					allPRs:    []PR{},
+					prsByRepo: make(map[string][]PR),
+					loaded:    true,
+				}
 			}
 		} else {
 			m.prCache = msg.cache
 		}
 		// After cache is loaded, filter repos to update PR counts
-		m.filterRepos()
+		m.refilterKeepingPosition()
 		return m, nil
 		
+	case issueCacheLoadedMsg:
+		m.finishJob(jobIssues, msg.err)
+		if msg.err != nil {
+			// Keep previously loaded issues, as with the PR cache
+			if m.issueCache == nil || !m.issueCache.loaded {
+				m.issueCache = &IssueCache{issuesByRepo: make(map[string][]Issue), loaded: true}
+			}
+		} else {
+			m.issueCache = msg.cache
+		}
+		if m.selectedRepo != nil {
+			m.repoIssues = m.issueCache.issuesByRepo[m.selectedRepo.GitHubURL]
+		}
+		m.refilterKeepingPosition()
+		return m, nil
+
+	case workflowRunsLoadedMsg:
+		m.finishJob(jobWorkflowRuns, msg.err)
+		if m.selectedRepo == nil || m.selectedRepo.GitHubURL != msg.repoURL {
+			// The user moved on to another repo
+			return m, nil
+		}
+		m.loadingRuns = false
+		m.runsRepoURL = msg.repoURL
+		if msg.err != nil {
+			m.workflowRuns = nil
+			m.runsLoadError = msg.err.Error()
+		} else {
+			m.workflowRuns = msg.runs
+			m.runsLoadError = ""
+		}
+		return m, nil
+
+	case workflowRerunMsg:
+		m.finishJob(jobRerun, msg.err)
+		if msg.err != nil {
+			return m, nil
+		}
+		noticeCmd := m.notify("Rerun of failed jobs requested")
+		// Reload so the rerun shows up as queued
+		if m.selectedRepo != nil && m.selectedRepo.GitHubURL == msg.repoURL {
+			var cmd tea.Cmd
+			m.runsRepoURL = ""
+			m.loadingRuns = false
+			m, cmd = m.loadDetailSection()
+			return m, tea.Batch(noticeCmd, cmd)
+		}
+		return m, noticeCmd
+
+	case repoMetadataLoadedMsg:
+		m.finishJob(jobMetadata, msg.err)
+		for i := range m.repos {
+			if md, ok := msg.metadata[m.repos[i].GitHubURL]; ok {
+				m.repos[i].Meta = &md
+			}
+		}
+		m.refilterKeepingPosition()
+		return m, nil
+
+	case remoteReposLoadedMsg:
+		m.remoteLoading = false
+		m.finishJob(jobRemoteRepos, msg.err)
+		m.remoteErr = msg.err
+		if msg.repos != nil {
+			m.remoteRepos = msg.repos
+		}
+		m.refilterKeepingPosition()
+		return m, nil
+
+	case repoClonedMsg:
+		delete(m.cloning, msg.repo.GitHubURL)
+		nameWithOwner, _ := repoNameWithOwner(msg.repo.GitHubURL)
+		m.finishJob(cloneJob(nameWithOwner), msg.err)
+		if msg.err != nil {
+			m.cloneErrors[msg.repo.GitHubURL] = msg.err.Error()
+			return m, nil
+		}
+		// The clone is now a local repo like any other
+		m.repos = append(m.repos, msg.repo)
+		m.refilterKeepingPosition()
+		if msg.thenCd {
+			return m, tea.Sequence(m.recordSelection(msg.repo.Directory), changeDirCmd(msg.repo.Directory))
+		}
+		return m, m.notify("Cloned " + nameWithOwner)
+
+	case syncEventMsg:
+		m.sync.apply(msg)
+		return m, waitForSyncEvent(m.sync.events)
+
+	case syncFinishedMsg:
+		m.sync.done = true
+		m.finishJob(jobSync, nil)
+		// Branches moved, so the status columns and filters are out of date
+		return m, tea.Batch(m.notify("Sync done: "+syncSummary(m.sync.results)), m.reloadLocalStatus())
+
+	case execEventsMsg:
+		for _, event := range msg.events {
+			m.exec.apply(event)
+		}
+		if !msg.done {
+			return m, waitForExecEvents(m.exec.events)
+		}
+		m.exec.done = true
+		m.finishJob(jobExec, nil)
+		// The command may have changed branches or working trees
+		return m, tea.Batch(m.notify("Command done: "+execSummary(m.exec.results)), m.reloadLocalStatus())
+
+	case localStatusLoadedMsg:
+		m.finishJob(jobLocalStatus, msg.err)
+		m.mergeLocalStatuses(msg.statuses)
+		if msg.complete {
+			m.loadingLocalStatus = false
+			m.localStatusComplete = true
+			m.refilterKeepingPosition()
+		}
+		return m, nil
+
+	case unreleasedCountsMsg:
+		m.finishJob(jobReleaseScan, nil)
+		m.mergeReleaseStatuses(msg.statuses)
+		return m, nil
+
+	case releaseInfoLoadedMsg:
+		m.finishJob(jobReleaseInfo, msg.err)
+		if m.selectedRepo == nil || m.selectedRepo.GitHubURL != msg.repoURL {
+			// The user moved on to another repo
+			return m, nil
+		}
+		m.loadingRelease = false
+		m.releaseRepoURL = msg.repoURL
+		// Local tags and counts are kept even when GitHub couldn't be asked
+		m.releaseInfo = msg.info
+		m.releaseLoadError = ""
+		if msg.err != nil {
+			m.releaseLoadError = msg.err.Error()
+		}
+		return m, nil
+
+	case notificationsLoadedMsg:
+		m.notificationsLoading = false
+		m.finishJob(jobNotifications, msg.err)
+		m.notificationsErr = msg.err
+		if msg.err == nil {
+			m.notifications = msg.notifications
+			m.notificationsTruncated = msg.truncated
+		}
+		m.clampNotificationCursor()
+		return m, nil
+
+	case notificationMarkedMsg:
+		m.finishJob(jobMarkRead, msg.err)
+		if msg.err != nil {
+			return m, nil
+		}
+		m.removeNotification(msg.id)
+		return m, m.notify("Marked as read")
+
 	case prLoadedMsg:
 		m.loadingPRs = false
+		m.finishJob(jobRepoPRs, msg.err)
 		if msg.err != nil {
 			m.prLoadError = msg.err.Error()
 		} else {
@@ -163,8 +564,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 		
 	case tea.KeyMsg:
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
 		if m.currentView == listView {
 			return m.updateListView(msg)
+		} else if m.currentView == notificationsView {
+			return m.updateNotificationsView(msg)
+		} else if m.currentView == savedSearchesView {
+			return m.updateSavedSearchesView(msg)
+		} else if m.currentView == syncView {
+			return m.updateSyncView(msg)
+		} else if m.currentView == execView {
+			return m.updateExecView(msg)
 		} else {
 			return m.updateDetailView(msg)
 		}
@@ -173,49 +585,94 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Paste {
+		// Pasted text is never a command, whatever it spells
+		if m.searchInput.update(msg) {
+			return m.handleSearchChange()
+		}
+		return m, nil
+	}
 	switch msg.String() {
 	case "ctrl+c":
 		return m, tea.Quit
 	case "ctrl+d":
 		if len(m.filteredRepos) > 0 {
 			repo := m.filteredRepos[m.cursor]
-			return m, changeDirCmd(repo.Directory)
-		}
+			if repo.RemoteOnly {
+				// Clone first, then cd into it
+				return m.cloneSelected(true)
+			}
+			// Save the selection before quitting
+			return m, tea.Sequence(m.recordSelection(repo.Directory), changeDirCmd(repo.Directory))
+		}
+	case "ctrl+y":
+		// Copy the selected repo's path to the clipboard
+		if len(m.filteredRepos) > 0 && !m.filteredRepos[m.cursor].RemoteOnly {
+			return m, copyPathCmd(m.filteredRepos[m.cursor].Directory)
+		}
+	case "ctrl+o":
+		// Switch to remote mode to search the configured orgs
+		return m.enterRemoteMode()
+	case "ctrl+l":
+		// Reload PRs now instead of waiting for the next periodic refresh
+		return m.refresh()
 	case "ctrl+p":
 		// Switch to PR mode and clear search
 		m.prMode = true
-		m.searchInput = ""
+		m.issueMode = false
+		m.remoteMode = false
+		m.searchInput.setValue("")
 		m.filterRepos()
 		return m, nil
+	case "ctrl+t":
+		// Switch to issues mode and clear search
+		return m.enterIssueMode()
+	case "ctrl+n":
+		return m.openNotificationsView()
+	case "ctrl+r":
+		// Cycle through the searches that led to a selection
+		return m.recallSearch()
+	case "ctrl+s":
+		return m.openSavedSearches()
+	case "tab":
+		// Mark the repo for sync and exec, like fzf's multi-select
+		m.toggleMark()
+		return m, nil
+	case "alt+s":
+		// Fetch and fast-forward the marked repos, or every repo in the list
+		return m.startSync()
+	case "alt+x":
+		// Run a shell command in every repo in the list
+		return m.openExec()
+	case "ctrl+g":
+		// Show or hide the branch and working tree columns
+		m.showStatusColumns = !m.showStatusColumns
+		return m, nil
+	case "alt+r":
+		// Toggle between queries and RE2 patterns
+		m.regexMode = !m.regexMode
+		return m.handleSearchChange()
 	case "up":
-		if m.cursor > 0 {
-			m.cursor--
-			// Scroll up if cursor goes above visible area
-			if m.cursor < m.scrollOffset {
-				m.scrollOffset = m.cursor
-			}
+		if m.cursor == 0 {
+			// Above the first row, ↑ recalls past searches like a shell
+			return m.recallSearch()
+		}
+		m.cursor--
+		// Scroll up if cursor goes above visible area
+		if m.cursor < m.scrollOffset {
+			m.scrollOffset = m.cursor
 		}
 	case "down":
 		if m.cursor < len(m.filteredRepos)-1 {
 			m.cursor++
-			// Calculate visible area height (terminal height minus header, search, footer, potential scroll indicators)
-			// Header(1) + 2 newlines(2) + search box with border(3) + 2 newlines(2) + newline before footer(1) + footer(1) = 10 lines
-			// Reserve 2 more lines for potential scroll indicators
-			visibleHeight := m.terminalHeight - 10 - 2
-			if visibleHeight < 1 {
-				visibleHeight = 1
-			}
+			visibleHeight := m.listVisibleHeight()
 			// Scroll down if cursor goes below visible area
 			if m.cursor >= m.scrollOffset+visibleHeight {
 				m.scrollOffset = m.cursor - visibleHeight + 1
 			}
 		}
 	case "pgup":
-		// Calculate visible area height for page jumps (reserve space for scroll indicators)
-		visibleHeight := m.terminalHeight - 10 - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.listVisibleHeight()
 		// Jump up by a page
 		m.cursor -= visibleHeight
 		if m.cursor < 0 {
@@ -226,11 +683,7 @@ func (m model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.scrollOffset = m.cursor
 		}
 	case "pgdown":
-		// Calculate visible area height for page jumps (reserve space for scroll indicators)
-		visibleHeight := m.terminalHeight - 10 - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.listVisibleHeight()
 		// Jump down by a page
 		m.cursor += visibleHeight
 		if m.cursor >= len(m.filteredRepos) {
@@ -241,6 +694,9 @@ func (m model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.scrollOffset = m.cursor - visibleHeight + 1
 		}
 	case "enter":
+		if len(m.filteredRepos) > 0 && m.filteredRepos[m.cursor].RemoteOnly {
+			return m.cloneSelected(false)
+		}
 		if len(m.filteredRepos) > 0 {
 			repo := m.filteredRepos[m.cursor]
 			m.selectedRepo = &repo
@@ -249,7 +705,22 @@ func (m model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailScrollOffset = 0
 			m.loadingPRs = false
 			m.prLoadError = ""
+			m.detailSection = detailSectionPRs
+			m.workflowRuns = nil
+			m.runsRepoURL = ""
+			m.loadingRuns = false
+			m.runsLoadError = ""
+			m.releaseInfo = nil
+			m.releaseRepoURL = ""
+			m.loadingRelease = false
+			m.releaseLoadError = ""
 			
+			// Issues mode shows the repo's issues instead of PRs
+			m.repoIssues = nil
+			if m.issueCache != nil && m.issueCache.loaded {
+				m.repoIssues = m.issueCache.issuesByRepo[repo.GitHubURL]
+			}
+
 			// Load PRs from cache instead of API call
 			if m.prCache != nil && m.prCache.loaded {
 				if cachedPRs, exists := m.prCache.prsByRepo[repo.GitHubURL]; exists {
@@ -260,30 +731,31 @@ func (m model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else {
 				m.repoDetails = []PR{}
 			}
-			return m, nil
+			return m, m.recordSelection(repo.Directory)
 		}
 	case "esc":
-		if len(m.searchInput) > 0 {
+		if m.searchInput.value() != "" {
 			// Clear search if there's text
-			m.searchInput = ""
+			m.searchInput.setValue("")
 			return m.handleSearchChange()
-		} else if m.prMode {
-			// Exit PR mode if search is already empty
+		} else if len(m.marked) > 0 {
+			// Then clear the marks
+			m.marked = nil
+			return m, nil
+		} else if m.prMode || m.issueMode || m.remoteMode {
+			// Exit PR/issues/remote mode if search is already empty
 			m.prMode = false
+			m.issueMode = false
+			m.remoteMode = false
 			m.filterRepos()
 			return m, nil
 		} else {
 			// Quit if search is already empty and in local mode
 			return m, tea.Quit
 		}
-	case "backspace":
-		if len(m.searchInput) > 0 {
-			m.searchInput = m.searchInput[:len(m.searchInput)-1]
-			return m.handleSearchChange()
-		}
 	default:
-		if len(msg.String()) == 1 {
-			m.searchInput += msg.String()
+		// Anything that isn't a command edits the search
+		if m.searchInput.update(msg) {
 			return m.handleSearchChange()
 		}
 	}
@@ -296,12 +768,18 @@ func (m model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case "ctrl+d":
 		if m.selectedRepo != nil {
-			return m, changeDirCmd(m.selectedRepo.Directory)
+			return m, tea.Sequence(m.recordSelection(m.selectedRepo.Directory), changeDirCmd(m.selectedRepo.Directory))
+		}
+	case "ctrl+y":
+		if m.selectedRepo != nil {
+			return m, copyPathCmd(m.selectedRepo.Directory)
 		}
 	case "ctrl+p":
 		// Switch to PR mode and go back to list view
 		m.prMode = true
-		m.searchInput = ""
+		m.issueMode = false
+		m.remoteMode = false
+		m.searchInput.setValue("")
 		m.currentView = listView
 		m.selectedRepo = nil
 		m.repoDetails = nil
@@ -309,11 +787,41 @@ func (m model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.detailScrollOffset = 0
 		m.filterRepos()
 		return m, nil
+	case "ctrl+t":
+		// Switch to issues mode and go back to list view
+		m.currentView = listView
+		m.selectedRepo = nil
+		m.repoDetails = nil
+		m.repoIssues = nil
+		m.detailCursor = 0
+		m.detailScrollOffset = 0
+		return m.enterIssueMode()
+	case "ctrl+n":
+		return m.openNotificationsView()
+	case "ctrl+l":
+		// Reload PRs now instead of waiting for the next periodic refresh
+		return m.refresh()
+	case "tab":
+		// Cycle through the detail sections
+		m.detailSection = (m.detailSection + 1) % detailSectionCount
+		m.detailCursor = 0
+		m.detailScrollOffset = 0
+		return m.loadDetailSection()
+	case "ctrl+r":
+		// Rerun the failed jobs of the selected workflow run
+		if m.detailSection == detailSectionRuns && m.detailCursor > 0 && m.detailCursor-1 < len(m.workflowRuns) {
+			run := m.workflowRuns[m.detailCursor-1]
+			if run.Failed() {
+				m.startJob(jobRerun)
+				return m, rerunFailedJobsCmd(m.gh, m.selectedRepo.GitHubURL, run.ID)
+			}
+		}
 	case "esc":
 		if m.startedInDetailView {
-			if m.prMode {
-				// Exit PR mode if in single repo detail view
+			if m.prMode || m.issueMode {
+				// Exit PR/issues mode if in single repo detail view
 				m.prMode = false
+				m.issueMode = false
 			} else {
 				return m, tea.Quit
 			}
@@ -332,30 +840,17 @@ func (m model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 	case "down":
-		maxItems := 1 // URL field
-		if len(m.repoDetails) > 0 {
-			maxItems += len(m.repoDetails)
-		}
+		maxItems := m.detailItemCount()
 		if m.detailCursor < maxItems-1 {
 			m.detailCursor++
-			// Calculate visible area height for detail view (reserve space for scroll indicators)
-			// Header(1) + 2 newlines(2) + Name(1) + 2 newlines(2) + URL(1) + 2 newlines(2) + "Pull Requests:"(1) + newline before footer(1) + footer(1) = 11 lines
-			// Reserve 2 more lines for potential scroll indicators
-			visibleHeight := m.terminalHeight - 11 - 2
-			if visibleHeight < 1 {
-				visibleHeight = 1
-			}
+			visibleHeight := m.detailVisibleHeight()
 			// Scroll down if cursor goes below visible area
 			if m.detailCursor >= m.detailScrollOffset+visibleHeight {
 				m.detailScrollOffset = m.detailCursor - visibleHeight + 1
 			}
 		}
 	case "pgup":
-		// Calculate visible area height for page jumps (reserve space for scroll indicators)
-		visibleHeight := m.terminalHeight - 11 - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.detailVisibleHeight()
 		// Jump up by a page
 		m.detailCursor -= visibleHeight
 		if m.detailCursor < 0 {
@@ -366,16 +861,9 @@ func (m model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailScrollOffset = m.detailCursor
 		}
 	case "pgdown":
-		// Calculate visible area height for page jumps (reserve space for scroll indicators)
-		visibleHeight := m.terminalHeight - 11 - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		// Calculate max items (URL field + PRs)
-		maxItems := 1 // URL field
-		if len(m.repoDetails) > 0 {
-			maxItems += len(m.repoDetails)
-		}
+		visibleHeight := m.detailVisibleHeight()
+		// Calculate max items (URL field + PRs or issues)
+		maxItems := m.detailItemCount()
 		// Jump down by a page
 		m.detailCursor += visibleHeight
 		if m.detailCursor >= maxItems {
@@ -390,31 +878,141 @@ func (m model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.detailCursor == 0 {
 				// Open repository URL
 				if m.selectedRepo.GitHubURL != "N/A" && m.selectedRepo.GitHubURL != "Non-GitHub" {
-					openURL(m.selectedRepo.GitHubURL)
+					return m, m.openInBrowser(m.selectedRepo.GitHubURL)
+				}
+			} else if m.detailSection == detailSectionReleases {
+				// Open the latest release, or the releases page
+				if m.releaseInfo != nil && m.releaseInfo.LatestReleaseURL != "" && m.detailCursor == 1 {
+					return m, m.openInBrowser(m.releaseInfo.LatestReleaseURL)
+				} else if m.selectedRepo.GitHubURL != "N/A" && m.selectedRepo.GitHubURL != "Non-GitHub" {
+					return m, m.openInBrowser(m.selectedRepo.GitHubURL + "/releases")
+				}
+			} else if m.detailSection == detailSectionRuns {
+				// Open workflow run URL
+				if m.detailCursor-1 < len(m.workflowRuns) {
+					return m, m.openInBrowser(m.workflowRuns[m.detailCursor-1].URL)
+				}
+			} else if m.issueMode {
+				// Open issue URL
+				if m.detailCursor-1 < len(m.repoIssues) {
+					return m, m.openInBrowser(m.repoIssues[m.detailCursor-1].URL)
 				}
 			} else if len(m.repoDetails) > 0 && m.detailCursor-1 < len(m.repoDetails) {
 				// Open PR URL
 				pr := m.repoDetails[m.detailCursor-1]
-				openURL(pr.URL)
+				return m, m.openInBrowser(pr.URL)
 			}
 		}
 	}
 	return m, nil
 }
 
+// detailItemCount is the number of selectable rows in the detail view:
+// the URL field followed by the rows of the active section.
+func (m model) detailItemCount() int {
+	if m.detailSection == detailSectionRuns {
+		return 1 + len(m.workflowRuns)
+	}
+	if m.detailSection == detailSectionReleases {
+		if m.releaseInfo == nil {
+			return 1
+		}
+		return 1 + len(m.releaseInfo.releaseLines(m.releaseLoadError))
+	}
+	if m.issueMode {
+		return 1 + len(m.repoIssues)
+	}
+	return 1 + len(m.repoDetails)
+}
+
+// loadDetailSection starts fetching the active detail section if it isn't
+// loaded for the selected repository yet.
+func (m model) loadDetailSection() (model, tea.Cmd) {
+	if m.selectedRepo == nil || m.detailSection == detailSectionPRs {
+		return m, nil
+	}
+
+	repoURL := m.selectedRepo.GitHubURL
+	isGitHub := repoURL != "N/A" && repoURL != "Non-GitHub"
+	canFetch := m.gh != nil && m.gh.authenticated()
+
+	switch m.detailSection {
+	case detailSectionRuns:
+		if m.runsRepoURL == repoURL || m.loadingRuns {
+			return m, nil
+		}
+		if !isGitHub {
+			m.workflowRuns = nil
+			m.runsLoadError = "not a GitHub repository"
+			m.runsRepoURL = repoURL
+			return m, nil
+		}
+		if !canFetch {
+			return m, nil
+		}
+		m.loadingRuns = true
+		m.runsLoadError = ""
+		m.startJob(jobWorkflowRuns)
+		return m, loadWorkflowRunsCmd(m.gh, *m.selectedRepo)
+	case detailSectionReleases:
+		if m.releaseRepoURL == repoURL || m.loadingRelease {
+			return m, nil
+		}
+		if !isGitHub {
+			m.releaseInfo = nil
+			m.releaseLoadError = "not a GitHub repository"
+			m.releaseRepoURL = repoURL
+			return m, nil
+		}
+		if m.gh == nil {
+			// Unauthenticated is fine; the local tags still load
+			return m, nil
+		}
+		m.loadingRelease = true
+		m.releaseLoadError = ""
+		m.startJob(jobReleaseInfo)
+		return m, loadReleaseInfoCmd(m.gh, *m.selectedRepo)
+	}
+	return m, nil
+}
+
 func (m model) handleSearchChange() (tea.Model, tea.Cmd) {
 	// Filter immediately since we're using cached data
 	m.filterRepos()
+	if m.startLocalStatusLoad() {
+		return m, loadLocalStatusCmd(m.repos, true)
+	}
 	return m, nil
 }
 
 func (m *model) filterRepos() {
-	if m.searchInput == "" {
-		// Show all repos with PR counts from cache
-		var allRepos []GitRepo
+	// Parse once per change of the search input
+	if m.searchInput.value() != m.queryInput || m.regexMode != m.queryRegex {
+		m.queryInput = m.searchInput.value()
+		m.queryRegex = m.regexMode
+		if m.regexMode {
+			m.query, m.queryErr = parseRegexQuery(m.queryInput)
+		} else {
+			m.query, m.queryErr = parseQuery(m.queryInput)
+		}
+	}
+	if m.queryErr != nil {
+		// Keep the last results on screen; the search box shows the error
+		return
+	}
+
+	if m.remoteMode {
+		m.filterRemoteRepos()
+	} else if m.issueMode {
+		// Issues mode groups issues by repo even with an empty search
+		m.filterReposByIssues()
+	} else if m.searchInput.value() == "" {
+		// Show all repos with PR counts from cache, most frecent first
+		var allRepos []scoredRepo
 		for _, repo := range m.repos {
 			repoCopy := repo
 			repoCopy.MatchingPRs = nil
+			repoCopy.MatchPositions = nil
 			// Update PR count from cache
 			if m.prCache != nil && m.prCache.loaded {
 				if cachedPRs, exists := m.prCache.prsByRepo[repo.GitHubURL]; exists {
@@ -423,39 +1021,38 @@ func (m *model) filterRepos() {
 					repoCopy.PRCount = 0
 				}
 			}
-			allRepos = append(allRepos, repoCopy)
+			allRepos = append(allRepos, scoredRepo{repo: repoCopy})
 		}
-		m.filteredRepos = allRepos
+		m.filteredRepos = rankRepos(allRepos, m.history)
 	} else if m.prMode {
 		// In PR mode, search for PRs by title/branch and filter repos that match
 		m.filterReposByPRs()
 	} else {
-		// Normal mode: filter by repository directory and URL
-		var filtered []GitRepo
-		searchLower := strings.ToLower(m.searchInput)
+		// Normal mode: filter by qualifiers, then rank by how well the
+		// repository directory or URL matches the free text
+		var matches []scoredRepo
 		
 		for _, repo := range m.repos {
-			dirLower := strings.ToLower(repo.Directory)
-			urlLower := strings.ToLower(repo.GitHubURL)
-			
-			if strings.Contains(dirLower, searchLower) ||
-			   strings.Contains(urlLower, searchLower) ||
-			   matchesMnemonic(dirLower, searchLower) ||
-			   matchesMnemonic(urlLower, searchLower) {
-				// Clear MatchingPRs in normal mode but update PR count from cache
-				repoCopy := repo
-				repoCopy.MatchingPRs = nil
-				if m.prCache != nil && m.prCache.loaded {
-					if cachedPRs, exists := m.prCache.prsByRepo[repo.GitHubURL]; exists {
-						repoCopy.PRCount = len(cachedPRs)
-					} else {
-						repoCopy.PRCount = 0
-					}
+			// Clear MatchingPRs in normal mode but update PR count from cache
+			repoCopy := repo
+			repoCopy.MatchingPRs = nil
+			if m.prCache != nil && m.prCache.loaded {
+				if cachedPRs, exists := m.prCache.prsByRepo[repo.GitHubURL]; exists {
+					repoCopy.PRCount = len(cachedPRs)
+				} else {
+					repoCopy.PRCount = 0
 				}
-				filtered = append(filtered, repoCopy)
+			}
+			if !m.query.matchesFields(repoCopy, m.cachedPRs(repo.GitHubURL), m.localStatuses) {
+				continue
+			}
+			
+			if score, positions, ok := m.query.matchRepo(repoCopy); ok {
+				repoCopy.MatchPositions = positions
+				matches = append(matches, scoredRepo{repo: repoCopy, score: score})
 			}
 		}
-		m.filteredRepos = filtered
+		m.filteredRepos = rankRepos(matches, m.history)
 	}
 	
 	// Reset cursor and scroll position
@@ -475,28 +1072,41 @@ func (m *model) filterReposByPRs() {
 		return
 	}
 	
-	// Search for PRs matching the search text by title only (branch info not available from search)
-	searchLower := strings.ToLower(m.searchInput)
-	var matchingPRs []PR
+	// Search for PRs matching the search text by title, number, branch,
+	// labels or repository, and the label: and body: qualifiers
+	type scoredPR struct {
+		pr    PR
+		score int
+	}
+	var matchingPRs []scoredPR
 	
 	for _, pr := range m.prCache.allPRs {
-		titleLower := strings.ToLower(pr.Title)
-		
-		// Check if search text matches PR title or mnemonic matching
-		if strings.Contains(titleLower, searchLower) || 
-		   matchesMnemonic(titleLower, searchLower) {
-			matchingPRs = append(matchingPRs, pr)
+		if !m.query.matchesPRFields(pr) {
+			continue
+		}
+		if score, positions, notes, ok := m.query.matchPR(pr); ok {
+			pr.MatchPositions = positions
+			pr.MatchNotes = notes
+			matchingPRs = append(matchingPRs, scoredPR{pr: pr, score: score})
 		}
 	}
 	
-	// Group matching PRs by repository URL
+	// Best matching PRs first, then group them by repository URL; a repo
+	// ranks by its best PR
+	sort.SliceStable(matchingPRs, func(i, j int) bool {
+		return matchingPRs[i].score > matchingPRs[j].score
+	})
 	prsByRepo := make(map[string][]PR)
-	for _, pr := range matchingPRs {
-		prsByRepo[pr.RepoURL] = append(prsByRepo[pr.RepoURL], pr)
+	repoScores := make(map[string]int)
+	for _, match := range matchingPRs {
+		if _, seen := prsByRepo[match.pr.RepoURL]; !seen {
+			repoScores[match.pr.RepoURL] = match.score
+		}
+		prsByRepo[match.pr.RepoURL] = append(prsByRepo[match.pr.RepoURL], match.pr)
 	}
 	
 	// Filter local repositories that match PR repositories and attach matching PRs
-	var filtered []GitRepo
+	var matches []scoredRepo
 	for _, repo := range m.repos {
 		if repo.GitHubURL != "N/A" && repo.GitHubURL != "Non-GitHub" {
 			if matchingPRs, exists := prsByRepo[repo.GitHubURL]; exists {
@@ -504,85 +1114,197 @@ func (m *model) filterReposByPRs() {
 				repoWithPRs := repo
 				repoWithPRs.MatchingPRs = matchingPRs
 				repoWithPRs.PRCount = len(m.prCache.prsByRepo[repo.GitHubURL]) // Total PRs, not just matching
-				filtered = append(filtered, repoWithPRs)
+				if m.query.matchesFields(repoWithPRs, matchingPRs, m.localStatuses) {
+					matches = append(matches, scoredRepo{repo: repoWithPRs, score: repoScores[repo.GitHubURL]})
+				}
 			}
 		}
 	}
 	
-	m.filteredRepos = filtered
+	m.filteredRepos = rankRepos(matches, m.history)
 }
 
-func matchesMnemonic(text, query string) bool {
-	if len(query) == 0 {
-		return true
+// mnemonicMatch matches the query against the words of the text, with each
+// piece of the query consuming a prefix of a word, in order: `oic` and
+// `opiscn` both match operations-istio-cni. It returns a score that favours
+// fewer, longer prefixes and words without gaps between them, and the rune
+// offsets that matched.
+func mnemonicMatch(text, query string) (int, []int, bool) {
+	queryRunes := []rune(query)
+	if len(queryRunes) == 0 {
+		return 0, nil, true
+	}
+	for i, r := range queryRunes {
+		queryRunes[i] = unicode.ToLower(r)
 	}
 	
-	words := extractWords(text)
+	runes := []rune(text)
+	words := wordSpans(runes)
 	
-	queryIndex := 0
-	for _, word := range words {
-		if queryIndex >= len(query) {
-			break
+	// best[w][q] is the best score for matching queryRunes[q:] using words[w:],
+	// with take[w][q] the length of the prefix of word w that path consumes
+	// (0 to skip it); started tells whether a word was already used, since
+	// only gaps after the first match cost
+	type state struct {
+		score int
+		take  int
+		ok    bool
+	}
+	memo := make(map[[3]int]state)
+	var solve func(w, q int, started bool) state
+	solve = func(w, q int, started bool) state {
+		if q == len(queryRunes) {
+			return state{ok: true}
+		}
+		if w == len(words) {
+			return state{}
+		}
+		key := [3]int{w, q, 0}
+		if started {
+			key[2] = 1
+		}
+		if cached, ok := memo[key]; ok {
+			return cached
+		}
+
+		// Skip this word
+		best := solve(w+1, q, started)
+		if best.ok {
+			if started {
+				best.score += scoreGapStart
+			}
+			best.take = 0
 		}
 		
-		if len(word) > 0 && strings.ToLower(string(word[0])) == strings.ToLower(string(query[queryIndex])) {
-			queryIndex++
+		// Or consume a prefix of it
+		word := runes[words[w][0]:words[w][1]]
+		for n := 1; n <= len(word) && q+n <= len(queryRunes); n++ {
+			if unicode.ToLower(word[n-1]) != queryRunes[q+n-1] {
+				break
+			}
+			rest := solve(w+1, q+n, true)
+			if !rest.ok {
+				continue
+			}
+			// Each further rune of a prefix earns more than starting another
+			// word would, so fewer, longer prefixes win
+			score := rest.score + n*scoreMatch + (n-1)*(bonusBoundary+bonusConsecutive)
+			if q == 0 {
+				score += bonusBoundary * bonusFirstChar
+			} else {
+				score += bonusBoundary
+			}
+			if !best.ok || score > best.score {
+				best = state{score: score, take: n, ok: true}
+			}
 		}
+
+		memo[key] = best
+		return best
+	}
+
+	result := solve(0, 0, false)
+	if !result.ok {
+		return 0, nil, false
 	}
 	
-	return queryIndex == len(query)
+	// Walk the chosen path again to collect the matched offsets
+	var positions []int
+	q, started := 0, false
+	for w := 0; q < len(queryRunes); w++ {
+		step := solve(w, q, started)
+		for i := 0; i < step.take; i++ {
+			positions = append(positions, words[w][0]+i)
+		}
+		if step.take > 0 {
+			started = true
+		}
+		q += step.take
+	}
+	return result.score, positions, true
 }
 
 func extractWords(text string) []string {
+	runes := []rune(text)
 	var words []string
-	var currentWord strings.Builder
-	
-	for i, r := range text {
-		if isWordBoundary(text, i) {
-			if currentWord.Len() > 0 {
-				words = append(words, currentWord.String())
-				currentWord.Reset()
-			}
+	for _, span := range wordSpans(runes) {
+		words = append(words, string(runes[span[0]:span[1]]))
+	}
+	return words
+}
+
+// wordSpans splits text into words, returned as [start, end) rune offsets.
+// Separators aren't part of any word.
+func wordSpans(runes []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range runes {
+		if start >= 0 && (!isWordRune(r) || isWordBoundary(runes, i)) {
+			spans = append(spans, [2]int{start, i})
+			start = -1
 		}
-		
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
-			currentWord.WriteRune(r)
+		if isWordRune(r) && start < 0 {
+			start = i
 		}
 	}
-	
-	if currentWord.Len() > 0 {
-		words = append(words, currentWord.String())
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(runes)})
 	}
-	
-	return words
+	return spans
 }
 
-func isWordBoundary(text string, pos int) bool {
+// isWordBoundary reports whether a word starts at rune offset pos: after a
+// separator such as -, _, / or a space, at a camelCase hump, where an
+// uppercase run gives way to a capitalised word (HTTP|Server), or where
+// letters and digits meet (k|8|s).
+func isWordBoundary(runes []rune, pos int) bool {
 	if pos == 0 {
 		return true
 	}
 	
-	if pos >= len(text) {
+	if pos >= len(runes) {
 		return false
 	}
 	
-	current := rune(text[pos])
-	prev := rune(text[pos-1])
+	current := runes[pos]
+	prev := runes[pos-1]
 	
-	if prev == '-' || prev == '_' || prev == '/' || prev == '\\' || prev == '.' {
+	if !isWordRune(prev) {
 		return true
 	}
 	
-	if (prev >= 'a' && prev <= 'z') && (current >= 'A' && current <= 'Z') {
+	if unicode.IsLower(prev) && unicode.IsUpper(current) {
 		return true
 	}
 	
+	if unicode.IsUpper(prev) && unicode.IsUpper(current) && pos+1 < len(runes) && unicode.IsLower(runes[pos+1]) {
+		return true
+	}
+
+	if !unicode.IsMark(current) && unicode.IsDigit(prev) != unicode.IsDigit(current) {
+		return true
+	}
+
 	return false
 }
 
+// isWordRune reports whether r is part of a word rather than a separator.
+// Combining marks belong to the letter before them.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
 func (m model) View() string {
 	if m.currentView == listView {
 		return m.renderListView()
+	} else if m.currentView == notificationsView {
+		return m.renderNotificationsView()
+	} else if m.currentView == savedSearchesView {
+		return m.renderSavedSearchesView()
+	} else if m.currentView == syncView {
+		return m.renderSyncView()
+	} else if m.currentView == execView {
+		return m.renderExecView()
 	} else {
 		return m.renderDetailView()
 	}
@@ -606,34 +1328,83 @@ func (m model) renderListView() string {
 	
 	if m.prMode {
 		b.WriteString(headerStyle.Render("Git Repository Explorer - PR Mode"))
+	} else if m.issueMode {
+		b.WriteString(headerStyle.Render("Git Repository Explorer - Issues Mode"))
+		if m.issueCache != nil && m.issueCache.omitted > 0 {
+			// GitHub's search stops at 1,000 results
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render(fmt.Sprintf(" (%d more issues not fetched)", m.issueCache.omitted)))
+		}
+	} else if m.remoteMode {
+		b.WriteString(headerStyle.Render("Git Repository Explorer - Remote Mode"))
 	} else {
 		b.WriteString(headerStyle.Render("Git Repository Explorer"))
 	}
+	if len(m.marked) > 0 {
+		b.WriteString(headerStyle.Render(fmt.Sprintf(" (%d marked)", len(m.marked))))
+	}
+	b.WriteString(m.renderGitHubIdentity())
 	b.WriteString("\n\n")
 	
-	var searchBox string
+	var searchLabel string
 	if m.prMode {
-		searchBox = fmt.Sprintf("PR Search: %s", m.searchInput)
+		searchLabel = "PR Search"
+	} else if m.issueMode {
+		searchLabel = "Issue Search"
+	} else if m.remoteMode {
+		searchLabel = "Org Search"
 	} else {
-		searchBox = fmt.Sprintf("Search: %s", m.searchInput)
+		searchLabel = "Search"
+	}
+	if m.regexMode {
+		searchLabel += " (regex)"
+	}
+	searchBox := fmt.Sprintf("%s: %s", searchLabel, m.searchInput.view())
+	if m.queryErr != nil {
+		queryErrorStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+		searchBox += queryErrorStyle.Render(fmt.Sprintf("  (%v)", m.queryErr))
+	} else if len(m.query.textQualifiers) > 0 {
+		// Qualifiers don't filter in regex mode; say so rather than ignore them
+		hintStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11"))
+		searchBox += hintStyle.Render(fmt.Sprintf("  (%s matched as text in regex mode)", strings.Join(m.query.textQualifiers, ", ")))
 	}
 	b.WriteString(searchStyle.Render(searchBox))
 	b.WriteString("\n\n")
 	
 	if len(m.filteredRepos) == 0 {
-		if m.prCache == nil || !m.prCache.loaded {
+		if m.remoteMode && len(m.config.Orgs) == 0 {
+			path, _ := configPath()
+			b.WriteString(fmt.Sprintf("No orgs configured. Add \"orgs\" to %s\n", path))
+		} else if m.remoteMode && (m.remoteLoading || m.gh == nil) {
+			b.WriteString("Loading org repositories...\n")
+		} else if m.remoteMode && m.remoteErr != nil {
+			b.WriteString(fmt.Sprintf("Org search unavailable: %v\n", m.remoteErr))
+		} else if m.remoteMode && m.gh.authError() != nil {
+			b.WriteString(fmt.Sprintf("Org search unavailable: %v\n", m.gh.authError()))
+		} else if m.remoteMode {
+			b.WriteString("No org repositories found matching your search.\n")
+		} else if m.issueMode && (m.issueCache == nil || !m.issueCache.loaded) {
+			b.WriteString("Loading issues...\n")
+		} else if m.issueMode && m.gh.authError() != nil {
+			b.WriteString(fmt.Sprintf("Issue search unavailable: %v\n", m.gh.authError()))
+		} else if m.issueMode {
+			b.WriteString("No issues assigned to or created by you match your search.\n")
+		} else if m.prCache == nil || !m.prCache.loaded {
 			b.WriteString("Loading PR cache...\n")
+		} else if m.prMode && m.gh.authError() != nil {
+			b.WriteString(fmt.Sprintf("PR search unavailable: %v\n", m.gh.authError()))
 		} else {
 			b.WriteString("No repositories found matching your search.\n")
 		}
 	} else {
 		minPaths := calculateMinimalPaths(m.filteredRepos)
 		
-		// Find the longest path to determine column width
+		// Find the widest path to determine column width
 		maxPathLen := 0
 		for _, path := range minPaths {
-			if len(path) > maxPathLen {
-				maxPathLen = len(path)
+			if width := uniseg.StringWidth(path); width > maxPathLen {
+				maxPathLen = width
 			}
 		}
 		
@@ -641,15 +1412,33 @@ func (m model) renderListView() string {
 			Foreground(lipgloss.Color("2")).
 			Bold(true)
 		
-		// Calculate visible area height (terminal height minus header, search, footer, scroll indicators)
-		// Header(1) + 2 newlines(2) + search box with border(3) + 2 newlines(2) + newline before footer(1) + footer(1) = 10 lines
+		unreleasedStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11"))
+
+		archivedStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")).
+			Faint(true)
+
+		loadingRowStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11"))
+
+		errorRowStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+
+		// Matched characters stand out in the list, and stay readable on the
+		// selected row's background
+		matchStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205")).
+			Bold(true)
+		selectedMatchStyle := selectedStyle.
+			Bold(true).
+			Underline(true)
+
+		markStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("13"))
+
 		// Always reserve 2 lines for scroll indicators (filled with empty lines if not needed)
-		baseOverhead := 10
-		scrollIndicatorLines := 2 // Always reserve 2 lines for consistent spacing
-		visibleHeight := m.terminalHeight - baseOverhead - scrollIndicatorLines
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.listVisibleHeight()
 		
 		// Determine which scroll indicators we need
 		showMoreAbove := m.scrollOffset > 0
@@ -662,6 +1451,8 @@ func (m model) renderListView() string {
 			endIdx = len(m.filteredRepos)
 		}
 		
+		branchWidth := m.branchColumnWidth(startIdx, endIdx)
+
 		// Always show exactly 2 lines for scroll indicators (use empty lines as padding)
 		if showMoreAbove {
 			b.WriteString("↑ (more above)\n")
@@ -671,12 +1462,49 @@ func (m model) renderListView() string {
 		
 		for i := startIdx; i < endIdx; i++ {
 			repo := m.filteredRepos[i]
-			pathColumn := fmt.Sprintf("%-*s", maxPathLen, minPaths[i])
+			pathStyle, highlightStyle := lipgloss.NewStyle(), matchStyle
+			if repo.Meta != nil && repo.Meta.Archived {
+				// Dim archived repos so active ones stand out
+				pathStyle = archivedStyle
+			}
+			if i == m.cursor {
+				pathStyle, highlightStyle = selectedStyle, selectedMatchStyle
+			}
+			// The minimal path is the tail of the directory the search matched
+			var pathPositions []int
+			if strings.HasSuffix(repo.Directory, minPaths[i]) {
+				pathLen := utf8.RuneCountInString(minPaths[i])
+				pathPositions = shiftPositions(repo.MatchPositions, utf8.RuneCountInString(repo.Directory)-pathLen, pathLen)
+			}
+			pathColumn := renderHighlighted(minPaths[i], pathPositions, pathStyle, highlightStyle)
+			pathColumn += pathStyle.Render(strings.Repeat(" ", maxPathLen-uniseg.StringWidth(minPaths[i])))
 			line := pathColumn
+			if len(m.marked) > 0 {
+				// A gutter for the marks, only while there are any
+				if m.marked[repo.Directory] {
+					line = markStyle.Render("●") + " " + line
+				} else {
+					line = "  " + line
+				}
+			}
 			
 			if repo.GitHubURL != "N/A" && repo.GitHubURL != "Non-GitHub" {
 				githubCheck := githubCheckStyle.Render("✓")
 				line = fmt.Sprintf("%s  %s", line, githubCheck)
+			} else {
+				line += "   "
+			}
+
+			if m.showStatusColumns {
+				line += m.renderStatusColumns(repo, branchWidth, i == m.cursor)
+			}
+
+			// Unreleased commits on the default branch since the last tag
+			// The column is " %4d unreleased", blank when there's nothing to release
+			if status, ok := m.releaseStatuses[repo.Directory]; ok && status.UnreleasedCommits > 0 {
+				line = fmt.Sprintf("%s %s", line, unreleasedStyle.Render(fmt.Sprintf("%4d unreleased", status.UnreleasedCommits)))
+			} else if len(m.releaseStatuses) > 0 {
+				line += strings.Repeat(" ", len(" 0000 unreleased"))
 			}
 			
 			// In PR mode, show matching PR names
@@ -685,28 +1513,87 @@ func (m model) renderListView() string {
 					Foreground(lipgloss.Color("8")). // Gray color for PR names
 					Italic(true)
 				
+				prMatchStyle := matchStyle.Italic(true)
+				if i == m.cursor {
+					prStyle, prMatchStyle = selectedStyle.Italic(true), selectedMatchStyle.Italic(true)
+				}
+
 				// Show first PR name, or count if multiple
 				if len(repo.MatchingPRs) == 1 {
 					// Extract just the title part (remove [owner/repo] prefix)
 					prTitle := repo.MatchingPRs[0].Title
+					titleStart := 0
 					if strings.Contains(prTitle, "] ") {
 						parts := strings.SplitN(prTitle, "] ", 2)
 						if len(parts) > 1 {
+							titleStart = utf8.RuneCountInString(prTitle) - utf8.RuneCountInString(parts[1])
 							prTitle = parts[1]
 						}
 					}
 					// Truncate if too long
-					if len(prTitle) > 40 {
-						prTitle = prTitle[:37] + "..."
+					ellipsis := ""
+					if uniseg.GraphemeClusterCount(prTitle) > 40 {
+						prTitle = clipGraphemes(prTitle, 37)
+						ellipsis = "..."
+					}
+					positions := shiftPositions(repo.MatchingPRs[0].MatchPositions, titleStart, utf8.RuneCountInString(prTitle))
+					prInfo := prStyle.Render(" → ") + renderHighlighted(prTitle, positions, prStyle, prMatchStyle) + prStyle.Render(ellipsis)
+					// Say why it matched when it wasn't the title
+					if notes := repo.MatchingPRs[0].MatchNotes; len(notes) > 0 {
+						prInfo += prMatchStyle.Render(" [" + strings.Join(notes, ", ") + "]")
 					}
-					prInfo := prStyle.Render(fmt.Sprintf(" → %s", prTitle))
 					line = fmt.Sprintf("%s%s", line, prInfo)
 				} else {
 					prInfo := prStyle.Render(fmt.Sprintf(" → %d PRs", len(repo.MatchingPRs)))
+					if fields := matchedPRFields(repo.MatchingPRs); len(fields) > 0 {
+						prInfo += prMatchStyle.Render(" [by " + strings.Join(fields, ", ") + "]")
+					}
 					line = fmt.Sprintf("%s%s", line, prInfo)
 				}
 			}
 			
+			// In remote mode, show which repos still need cloning
+			if repo.RemoteOnly {
+				remoteStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color("8")).
+					Italic(true)
+
+				if m.cloning[repo.GitHubURL] {
+					line = fmt.Sprintf("%s%s", line, loadingRowStyle.Render(" → cloning..."))
+				} else if cloneErr, failed := m.cloneErrors[repo.GitHubURL]; failed {
+					line = fmt.Sprintf("%s%s", line, errorRowStyle.Render(" → clone failed: "+cloneErr))
+				} else {
+					line = fmt.Sprintf("%s%s", line, remoteStyle.Render(" → not cloned"))
+				}
+			}
+
+			// In issues mode, show matching issue titles the same way
+			if m.issueMode && len(repo.MatchingIssues) > 0 {
+				issueStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color("8")).
+					Italic(true)
+
+				issueMatchStyle := matchStyle.Italic(true)
+				if i == m.cursor {
+					issueStyle, issueMatchStyle = selectedStyle.Italic(true), selectedMatchStyle.Italic(true)
+				}
+
+				if len(repo.MatchingIssues) == 1 {
+					issue := repo.MatchingIssues[0]
+					issueTitle := issue.Title
+					ellipsis := ""
+					if uniseg.GraphemeClusterCount(issueTitle) > 40 {
+						issueTitle = clipGraphemes(issueTitle, 37)
+						ellipsis = "..."
+					}
+					positions := shiftPositions(issue.MatchPositions, 0, utf8.RuneCountInString(issueTitle))
+					issueInfo := issueStyle.Render(fmt.Sprintf(" → #%d ", issue.Number)) + renderHighlighted(issueTitle, positions, issueStyle, issueMatchStyle) + issueStyle.Render(ellipsis)
+					line = fmt.Sprintf("%s%s", line, issueInfo)
+				} else {
+					line = fmt.Sprintf("%s%s", line, issueStyle.Render(fmt.Sprintf(" → %d issues", len(repo.MatchingIssues))))
+				}
+			}
+
 			if i == m.cursor {
 				line = selectedStyle.Render(line)
 			}
@@ -723,15 +1610,34 @@ func (m model) renderListView() string {
 	}
 	
 	b.WriteString("\n")
-	if m.prMode {
-		b.WriteString("PR Mode: Search your GitHub PRs, repos shown match PR repositories. Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter for details, Ctrl+D to cd and exit, Esc to clear search/exit PR mode, Ctrl+C to quit")
-	} else {
-		b.WriteString("Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter for details, Ctrl+D to cd and exit, Ctrl+P for PR mode, Esc to clear search/quit, Ctrl+C to quit")
-	}
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.listFooter()))
 	
 	return b.String()
 }
 
+// listFooter is the key help under the list, for the current mode.
+func (m model) listFooter() string {
+	switch {
+	case m.prMode:
+		return "PR Mode: Search your GitHub PRs, repos shown match PR repositories. Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter for details, Ctrl+D to cd and exit, Ctrl+R for past searches, Alt+R for regex, Ctrl+S for saved searches, Ctrl+G for git status, Tab to mark, Alt+S to sync, Alt+X to run a command, Ctrl+T for issues mode, Esc to clear search/exit PR mode, Ctrl+C to quit"
+	case m.remoteMode:
+		return "Remote Mode: Search your orgs' repositories. Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter to clone or show details, Ctrl+D to clone/cd and exit, Esc to clear search/exit remote mode, Ctrl+C to quit"
+	case m.issueMode:
+		return "Issues Mode: Search issues assigned to or created by you. Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter for issues, Ctrl+D to cd and exit, Ctrl+P for PR mode, Esc to clear search/exit issues mode, Ctrl+C to quit"
+	default:
+		return "Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter for details, Ctrl+D to cd and exit, Ctrl+Y to copy path, Ctrl+R for past searches, Alt+R for regex, Ctrl+S for saved searches, Ctrl+G for git status, Tab to mark, Alt+S to sync, Alt+X to run a command, Ctrl+P for PR mode, Ctrl+T for issues mode, Ctrl+O for org search, Ctrl+L to reload, Esc to clear search/marks/quit, Ctrl+C to quit"
+	}
+}
+
+// listVisibleHeight is how many rows of the list fit on screen.
+func (m model) listVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + search box with border(3) + 2 newlines(2) + newline before footer(1) + status bar(1) = 10 lines,
+	// the footer, and 2 lines reserved for scroll indicators
+	return max(m.terminalHeight-10-m.footerHeight(m.listFooter())-2, 1)
+}
+
 func (m model) renderDetailView() string {
 	var b strings.Builder
 	
@@ -758,6 +1664,7 @@ func (m model) renderDetailView() string {
 	}
 	
 	b.WriteString(headerStyle.Render("Repository Details"))
+	b.WriteString(m.renderGitHubIdentity())
 	b.WriteString("\n\n")
 	
 	b.WriteString(labelStyle.Render("Name: "))
@@ -770,91 +1677,254 @@ func (m model) renderDetailView() string {
 		urlLine = selectedStyle.Render(urlLine)
 	}
 	b.WriteString(urlLine)
+	if m.selectedRepo.OriginErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf(" (origin unreadable: %v)", m.selectedRepo.OriginErr)))
+	}
 	b.WriteString("\n\n")
 	
-	b.WriteString(labelStyle.Render("Pull Requests:"))
+	// Section tabs, switched with Tab
+	inactiveTabStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+	itemsLabel := "Pull Requests"
+	if m.issueMode {
+		itemsLabel = "Issues"
+	}
+	for section, label := range []string{itemsLabel, "Workflow Runs", "Releases"} {
+		if section > 0 {
+			b.WriteString(inactiveTabStyle.Render(" | "))
+		}
+		if detailSection(section) == m.detailSection {
+			b.WriteString(labelStyle.Render(label + ":"))
+		} else {
+			b.WriteString(inactiveTabStyle.Render(label))
+		}
+	}
 	b.WriteString("\n")
 	
-	if m.loadingPRs {
+	if m.detailSection == detailSectionReleases {
+		if m.loadingRelease || m.gh == nil {
+			b.WriteString(loadingStyle.Render("Loading releases..."))
+			b.WriteString("\n")
+		} else if m.releaseInfo != nil {
+			m.renderDetailItems(&b, m.releaseInfo.releaseLines(m.releaseLoadError), selectedStyle)
+		} else if m.releaseLoadError != "" {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %s", m.releaseLoadError)))
+			b.WriteString("\n")
+		} else {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.gh.authError())))
+			b.WriteString("\n")
+		}
+	} else if m.detailSection == detailSectionRuns {
+		if m.loadingRuns || m.gh == nil {
+			b.WriteString(loadingStyle.Render("Loading workflow runs..."))
+			b.WriteString("\n")
+		} else if m.runsLoadError != "" {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %s", m.runsLoadError)))
+			b.WriteString("\n")
+		} else if m.gh != nil && !m.gh.authenticated() {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.gh.authError())))
+			b.WriteString("\n")
+		} else if len(m.workflowRuns) == 0 {
+			b.WriteString("No workflow runs on the default or current branch")
+			b.WriteString("\n")
+		} else {
+			var runLines []string
+			for i, run := range m.workflowRuns {
+				// Older runs sit under the latest one of their workflow
+				repeat := i > 0 && m.workflowRuns[i-1].WorkflowID == run.WorkflowID && m.workflowRuns[i-1].Branch == run.Branch
+				runLines = append(runLines, renderWorkflowRun(run, repeat))
+			}
+			m.renderDetailItems(&b, runLines, selectedStyle)
+		}
+	} else if m.issueMode {
+		if m.issueCache == nil || !m.issueCache.loaded {
+			b.WriteString(loadingStyle.Render("Loading issues..."))
+			b.WriteString("\n")
+		} else if m.gh != nil && !m.gh.authenticated() {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.gh.authError())))
+			b.WriteString("\n")
+		} else if len(m.repoIssues) == 0 {
+			b.WriteString("No open issues assigned to or created by current user")
+			b.WriteString("\n")
+		} else {
+			var issueLines []string
+			for _, issue := range m.repoIssues {
+				issueLines = append(issueLines, fmt.Sprintf("#%d: %s (%s)", issue.Number, issue.Title, issue.Role))
+			}
+			m.renderDetailItems(&b, issueLines, selectedStyle)
+		}
+	} else if m.loadingPRs {
 		b.WriteString(loadingStyle.Render("Loading PRs..."))
 		b.WriteString("\n")
 	} else if m.prLoadError != "" {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %s", m.prLoadError)))
 		b.WriteString("\n")
+	} else if m.gh != nil && !m.gh.authenticated() {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.gh.authError())))
+		b.WriteString("\n")
 	} else if len(m.repoDetails) == 0 {
 		b.WriteString("No open PRs by current user")
 		b.WriteString("\n")
 	} else {
-		// Calculate visible area height for PR list (reserve space for scroll indicators)
-		// Header(1) + 2 newlines(2) + Name(1) + 2 newlines(2) + URL(1) + 2 newlines(2) + "Pull Requests:"(1) + newline before footer(1) + footer(1) = 11 lines
-		// Reserve 2 more lines for potential scroll indicators
-		visibleHeight := m.terminalHeight - 11 - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		
-		// Calculate total items (URL field + PRs)
-		totalItems := 1 + len(m.repoDetails)
-		
-		// Calculate which PRs to show (accounting for URL field at index 0)
-		startPRIdx := 0
-		endPRIdx := len(m.repoDetails)
-		
-		if totalItems > visibleHeight {
-			// Determine the visible range considering the cursor position
-			if m.detailScrollOffset > 0 {
-				// If we're scrolled past the URL field, show "more above" indicator
-				b.WriteString("↑ (more above)\n")
-			}
-			
-			// Calculate PR range to display
-			prStartOffset := m.detailScrollOffset - 1 // Subtract 1 for URL field
-			if prStartOffset < 0 {
-				prStartOffset = 0
-			}
-			
-			prVisibleCount := visibleHeight
-			if m.detailScrollOffset == 0 {
-				prVisibleCount-- // Account for URL field being visible
-			}
-			
-			startPRIdx = prStartOffset
-			endPRIdx = prStartOffset + prVisibleCount
-			if endPRIdx > len(m.repoDetails) {
-				endPRIdx = len(m.repoDetails)
-			}
-		}
-		
-		for i := startPRIdx; i < endPRIdx; i++ {
-			pr := m.repoDetails[i]
-			prLine := fmt.Sprintf("#%d: %s", pr.Number, pr.Title)
-			if m.detailCursor == i+1 {
-				prLine = selectedStyle.Render(prLine)
-			}
-			b.WriteString(prLine)
-			b.WriteString("\n")
-		}
-		
-		// Show "more below" indicator if needed
-		if endPRIdx < len(m.repoDetails) {
-			b.WriteString("↓ (more below)\n")
+		var prLines []string
+		for _, pr := range m.repoDetails {
+			prLines = append(prLines, fmt.Sprintf("#%d: %s", pr.Number, pr.Title))
 		}
+		m.renderDetailItems(&b, prLines, selectedStyle)
 	}
 	
 	b.WriteString("\n")
-	if m.prMode {
-		b.WriteString("Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter to open, Ctrl+D to cd and exit, Esc to go back/exit PR mode, Ctrl+C to quit")
-	} else {
-		b.WriteString("Use ↑/↓ to navigate, PgUp/PgDn for pages, Enter to open, Ctrl+D to cd and exit, Ctrl+P for PR mode, Esc to go back, Ctrl+C to quit")
-	}
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.detailFooter()))
 	
 	return b.String()
 }
 
+// detailFooter is the key help under the detail view, for the current
+// section and mode.
+func (m model) detailFooter() string {
+	switch {
+	case m.detailSection == detailSectionReleases:
+		return "Use ↑/↓ to navigate, Tab to switch section, Enter to open release, Ctrl+D to cd and exit, Esc to go back, Ctrl+C to quit"
+	case m.detailSection == detailSectionRuns:
+		return "Use ↑/↓ to navigate, Tab to switch section, Enter to open run, Ctrl+R to rerun failed jobs, Ctrl+D to cd and exit, Esc to go back, Ctrl+C to quit"
+	case m.prMode:
+		return "Use ↑/↓ to navigate, PgUp/PgDn for pages, Tab to switch section, Enter to open, Ctrl+D to cd and exit, Esc to go back/exit PR mode, Ctrl+C to quit"
+	case m.issueMode:
+		return "Use ↑/↓ to navigate, PgUp/PgDn for pages, Tab to switch section, Enter to open, Ctrl+D to cd and exit, Esc to go back/exit issues mode, Ctrl+C to quit"
+	default:
+		return "Use ↑/↓ to navigate, PgUp/PgDn for pages, Tab to switch section, Enter to open, Ctrl+D to cd and exit, Ctrl+Y to copy path, Ctrl+P for PR mode, Ctrl+L to reload, Esc to go back, Ctrl+C to quit"
+	}
+}
+
+// detailVisibleHeight is how many items of the detail view's list fit on
+// screen.
+func (m model) detailVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + Name(1) + 2 newlines(2) + URL(1) + 2 newlines(2) + section label(1) + newline before footer(1) + status bar(1) = 11 lines,
+	// the footer, and 2 lines reserved for scroll indicators
+	return max(m.terminalHeight-11-m.footerHeight(m.detailFooter())-2, 1)
+}
+
+// renderGitHubIdentity shows who qgh is talking to GitHub as and the remaining
+// API quota, next to the header.
+func (m model) renderGitHubIdentity() string {
+	identityStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	if m.gh == nil {
+		// Still connecting; a persisted cache can already say how old it is
+		if m.prCache != nil && !m.prCache.fetchedAt.IsZero() {
+			return identityStyle.Render(fmt.Sprintf("  PRs from %s ago", formatAge(time.Since(m.prCache.fetchedAt))))
+		}
+		return ""
+	}
+	if !m.gh.authenticated() {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+		state := "unavailable"
+		if errors.Is(m.gh.Err, errGHNotAuthenticated) {
+			state = "not authenticated"
+		}
+		status := warningStyle.Render(fmt.Sprintf("  (GitHub: %s)", state))
+		if m.prCache != nil && !m.prCache.fetchedAt.IsZero() {
+			status += identityStyle.Render(fmt.Sprintf(" · cached PRs from %s ago", formatAge(time.Since(m.prCache.fetchedAt))))
+		}
+		return status
+	}
+	status := fmt.Sprintf("  @%s on %s", m.gh.Login, m.gh.Host)
+	if m.prCache != nil && !m.prCache.fetchedAt.IsZero() {
+		status += fmt.Sprintf(" · PRs from %s ago", formatAge(time.Since(m.prCache.fetchedAt)))
+	}
+	if rl, ok := m.gh.quota(); ok {
+		status += fmt.Sprintf(" · %s quota %d/%d, resets %s", rl.Resource, rl.Remaining, rl.Limit, rl.Reset.Format("15:04"))
+	}
+	return identityStyle.Render(status)
+}
+
+// renderDetailItems writes the scrollable item list below the URL field.
+// Item i is selected when the detail cursor is at i+1, since the URL field
+// takes index 0.
+func (m model) renderDetailItems(b *strings.Builder, items []string, selectedStyle lipgloss.Style) {
+	visibleHeight := m.detailVisibleHeight()
+
+	// Calculate total items (URL field + items)
+	totalItems := 1 + len(items)
+
+	// Calculate which items to show (accounting for URL field at index 0)
+	startIdx := 0
+	endIdx := len(items)
+
+	if totalItems > visibleHeight {
+		// Determine the visible range considering the cursor position
+		if m.detailScrollOffset > 0 {
+			// If we're scrolled past the URL field, show "more above" indicator
+			b.WriteString("↑ (more above)\n")
+		}
+
+		// Calculate item range to display
+		itemStartOffset := m.detailScrollOffset - 1 // Subtract 1 for URL field
+		if itemStartOffset < 0 {
+			itemStartOffset = 0
+		}
+
+		itemVisibleCount := visibleHeight
+		if m.detailScrollOffset == 0 {
+			itemVisibleCount-- // Account for URL field being visible
+		}
+
+		startIdx = itemStartOffset
+		endIdx = itemStartOffset + itemVisibleCount
+		if endIdx > len(items) {
+			endIdx = len(items)
+		}
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		line := items[i]
+		if m.detailCursor == i+1 {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	// Show "more below" indicator if needed
+	if endIdx < len(items) {
+		b.WriteString("↓ (more below)\n")
+	}
+}
+
+// subcommands are the words that run a command instead of searching.
+var subcommands = []string{"history", "sync", "exec"}
+
+// subcommand returns the subcommand named on the command line, or "" to
+// start the TUI. A search that's spelled like one goes after --, as in
+// qgh -- sync.
+func subcommand() string {
+	if flag.NArg() == 0 || !slices.Contains(subcommands, flag.Arg(0)) {
+		return ""
+	}
+	if i := len(os.Args) - flag.NArg() - 1; i > 0 && os.Args[i] == "--" {
+		return ""
+	}
+	return flag.Arg(0)
+}
+
 func main() {
 	skipIgnore := flag.Bool("skip-ignore", false, "Skip .gitignore files and traverse all directories")
 	prMode := flag.Bool("pr", false, "PR search mode: search through user's PRs and show matching repositories")
+	issueMode := flag.Bool("issues", false, "Issues search mode: search issues assigned to or created by the user")
 	flag.Parse()
+	command := subcommand()
+
+	if command == "history" {
+		if err := runHistoryCommand(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Get optional search term from positional arguments
 	var initialSearch string
@@ -871,12 +1941,39 @@ func main() {
 	// Check if QGH_WORKSPACE should be used instead of current directory
 	searchDir := getSearchDirectory(workingDir)
 
+	config, configErr := loadConfig()
+	if configErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", configErr)
+	}
+	// qgh @name runs a saved search
+	var savedSearch *SavedSearch
+	if name, found := strings.CutPrefix(initialSearch, "@"); found {
+		search, ok := config.savedSearch(name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: no saved search named %q\n", name)
+			os.Exit(1)
+		}
+		savedSearch = &search
+	}
+	history, historyErr := loadHistory()
+	searches, searchesErr := loadSearchHistory()
+	if historyErr == nil {
+		historyErr = searchesErr
+	}
+
 	repos, err := findGitRepositories(searchDir, *skipIgnore)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error finding git repositories: %v\n", err)
 		os.Exit(1)
 	}
 
+	if command == "sync" {
+		os.Exit(runSyncCommand(subcommandRepos(repos, searchDir), config, flag.Args()[1:]))
+	}
+	if command == "exec" {
+		os.Exit(runExecCommand(subcommandRepos(repos, searchDir), config, flag.Args()[1:]))
+	}
+
 	// Check if we're in a git repo with no subdirectories
 	if len(repos) == 0 && isGitRepository(searchDir) {
 		currentRepo, err := getCurrentRepoInfo(searchDir)
@@ -885,7 +1982,6 @@ func main() {
 			m := model{
 				repos:         []GitRepo{*currentRepo},
 				filteredRepos: []GitRepo{*currentRepo},
-				searchInput:   "",
 				cursor:        0,
 				prCache:       nil, // Will be loaded in Init()
 				currentView:   detailView,
@@ -895,9 +1991,20 @@ func main() {
 				loadingPRs:    true,
 				prLoadError:   "",
 				startedInDetailView: true,
-				terminalHeight: 24, // Default height, will be updated by WindowSizeMsg
-				prMode:        *prMode,
+				terminalHeight:      24, // Default height, will be updated by WindowSizeMsg
+				prMode:              *prMode && !*issueMode,
+				issueMode:           *issueMode,
+				cloning:             make(map[string]bool),
+				cloneErrors:         make(map[string]string),
+				config:              config,
+				workspaceRoot:       cloneRoot(searchDir),
+				history:             history,
+				searchHistory:       searches,
 			}
+			// Init connects to GitHub, which then loads the PRs
+			m.startJob(jobConnect)
+			m.startJob(jobRepoPRs)
+			m.reportStartupErrors(configErr, historyErr)
 			
 			p := tea.NewProgram(m, tea.WithAltScreen())
 			if _, err := p.Run(); err != nil {
@@ -915,29 +2022,40 @@ func main() {
 
 	if isInteractive() {
 		m := model{
-			repos:         repos,
-			filteredRepos: repos,
-			searchInput:   initialSearch,
-			cursor:        0,
-			prCache:       nil, // Will be loaded in Init()
-			currentView:   listView,
-			selectedRepo:  nil,
-			repoDetails:   nil,
-			detailCursor:  0,
-			loadingPRs:    false,
-			prLoadError:   "",
+			repos:               repos,
+			filteredRepos:       repos,
+			cursor:              0,
+			prCache:             loadPersistedPRCache(), // Shown right away, revalidated once GitHub is connected
+			currentView:         listView,
+			selectedRepo:        nil,
+			repoDetails:         nil,
+			detailCursor:        0,
+			loadingPRs:          false,
+			prLoadError:         "",
 			startedInDetailView: false,
-			terminalHeight: 24, // Default height, will be updated by WindowSizeMsg
-			prMode:        *prMode,
+			terminalHeight:      24, // Default height, will be updated by WindowSizeMsg
+			prMode:              *prMode && !*issueMode,
+			issueMode:           *issueMode,
+			cloning:             make(map[string]bool),
+			cloneErrors:         make(map[string]string),
+			config:              config,
+			workspaceRoot:       cloneRoot(searchDir),
+			history:             history,
+			searchHistory:       searches,
+			showStatusColumns:   config.StatusColumns,
 		}
 		
-		// Apply initial filter if search term provided
-		if initialSearch != "" {
-			// Don't filter yet if we have initial search, wait for cache to load
-			if !*prMode {
-				m.filterRepos()
-			}
+		// Apply the initial filter right away; with a persisted PR cache even
+		// PR mode has results before GitHub answers
+		if savedSearch != nil {
+			m.useSavedSearch(*savedSearch)
+		} else {
+			m.searchInput.setValue(initialSearch)
 		}
+		m.filterRepos()
+		m.startLocalStatusLoad()
+		m.startJob(jobConnect)
+		m.reportStartupErrors(configErr, historyErr)
 		
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
@@ -967,8 +2085,12 @@ func getCurrentRepoInfo(dir string) (*GitRepo, error) {
 	}
 	
 	origin, err := getOriginRemote(dir)
+	var originErr error
 	if err != nil {
 		origin = "N/A"
+		if !errors.Is(err, errNoOrigin) {
+			originErr = err
+		}
 	}
 	
 	githubURL := convertToGitHubURL(origin)
@@ -978,6 +2100,7 @@ func getCurrentRepoInfo(dir string) (*GitRepo, error) {
 		Origin:    origin,
 		GitHubURL: githubURL,
 		PRCount:   0,
+		OriginErr: originErr,
 	}, nil
 }
 
@@ -1016,8 +2139,12 @@ func findGitRepositories(rootDir string, skipIgnore bool) ([]GitRepo, error) {
 			}
 
 			origin, err := getOriginRemote(repoDir)
+			var originErr error
 			if err != nil {
 				origin = "N/A"
+				if !errors.Is(err, errNoOrigin) {
+					originErr = err
+				}
 			}
 
 			githubURL := convertToGitHubURL(origin)
@@ -1027,6 +2154,7 @@ func findGitRepositories(rootDir string, skipIgnore bool) ([]GitRepo, error) {
 				Origin:    origin,
 				GitHubURL: githubURL,
 				PRCount:   0, // Will be loaded on-demand in detail view
+				OriginErr: originErr,
 			})
 
 			return filepath.SkipDir
@@ -1084,127 +2212,92 @@ func shouldSkipDirectory(dirPath string) bool {
 	return false
 }
 
+// errNoOrigin means the repository has no origin remote, which isn't a failure.
+var errNoOrigin = errors.New("no origin remote")
+
 func getOriginRemote(repoDir string) (string, error) {
 	cmd := exec.Command("git", "-C", repoDir, "remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// git exits with 2 when the remote doesn't exist
+			if exitErr.ExitCode() == 2 {
+				return "", errNoOrigin
+			}
+			return "", fmt.Errorf("%s", ghErrorText(err, string(exitErr.Stderr)))
+		}
 		return "", err
 	}
 
 	return strings.TrimSpace(string(output)), nil
 }
 
-func convertToGitHubURL(origin string) string {
-	if origin == "N/A" || origin == "" {
-		return "N/A"
-	}
-
-	sshRegex := regexp.MustCompile(`^(?:ssh://)?git@github\.com[:/](.+)/(.+?)(?:\.git)?$`)
-	httpsRegex := regexp.MustCompile(`^https://github\.com/(.+)/(.+?)(?:\.git)?$`)
-
-	if matches := sshRegex.FindStringSubmatch(origin); len(matches) == 3 {
-		return fmt.Sprintf("https://github.com/%s/%s", matches[1], matches[2])
-	}
-
-	if matches := httpsRegex.FindStringSubmatch(origin); len(matches) == 3 {
-		return fmt.Sprintf("https://github.com/%s/%s", matches[1], matches[2])
-	}
-
-	if strings.Contains(origin, "github.com") {
-		return origin
+// getCurrentBranch returns the checked-out branch, or "" for a detached HEAD.
+func getCurrentBranch(repoDir string) string {
+	cmd := exec.Command("git", "-C", repoDir, "symbolic-ref", "--quiet", "--short", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
 	}
-
-	return "Non-GitHub"
-}
-
-var ghAuthWarningShown = false
-
-func checkGitHubAuth() bool {
-	cmd := exec.Command("gh", "auth", "status")
-	err := cmd.Run()
-	return err == nil
+	return strings.TrimSpace(string(output))
 }
 
-func getPRCount(repoURL string) int {
-	if repoURL == "N/A" || repoURL == "Non-GitHub" {
-		return 0
+// getDefaultBranch reads the remote's default branch from origin/HEAD, which
+// is only set for clones; it returns "" when unknown.
+func getDefaultBranch(repoDir string) string {
+	cmd := exec.Command("git", "-C", repoDir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
 	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+}
 
-	// Check GitHub CLI authentication once
-	if !ghAuthWarningShown {
-		if !checkGitHubAuth() {
-			fmt.Fprintf(os.Stderr, "Warning: GitHub CLI not authenticated. PR counts will be unavailable.\n")
-			fmt.Fprintf(os.Stderr, "Run 'gh auth login' to enable PR count features.\n\n")
-			ghAuthWarningShown = true
-			return 0
-		}
-		ghAuthWarningShown = true
-	}
+// Origin remotes in SSH and HTTPS form, on any host; convertToGitHubURL
+// keeps the ones on GitHub.
+var (
+	sshOriginRegex   = regexp.MustCompile(`^(?:ssh://)?git@([^:/]+)[:/](.+)/(.+?)(?:\.git)?$`)
+	httpsOriginRegex = regexp.MustCompile(`^https://([^/@]+@)?([^/]+)/(.+)/(.+?)(?:\.git)?$`)
+)
 
-	// Extract owner/repo from GitHub URL
-	re := regexp.MustCompile(`https://github\.com/([^/]+)/([^/]+)`)
-	matches := re.FindStringSubmatch(repoURL)
-	if len(matches) != 3 {
-		return 0
+func convertToGitHubURL(origin string) string {
+	if origin == "N/A" || origin == "" {
+		return "N/A"
 	}
 
-	owner := matches[1]
-	repo := matches[2]
-
-	// Get current user
-	userCmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	userOutput, err := userCmd.Output()
-	if err != nil {
-		return 0
+	if matches := sshOriginRegex.FindStringSubmatch(origin); matches != nil && isGitHubHost(matches[1]) {
+		return fmt.Sprintf("https://%s/%s/%s", matches[1], matches[2], matches[3])
 	}
-	currentUser := strings.TrimSpace(string(userOutput))
 
-	// Get PR count for current user
-	prCmd := exec.Command("gh", "pr", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--author", currentUser, "--json", "number")
-	prOutput, err := prCmd.Output()
-	if err != nil {
-		return 0
+	if matches := httpsOriginRegex.FindStringSubmatch(origin); matches != nil && isGitHubHost(matches[2]) {
+		return fmt.Sprintf("https://%s/%s/%s", matches[2], matches[3], matches[4])
 	}
 
-	var prs []map[string]interface{}
-	if err := json.Unmarshal(prOutput, &prs); err != nil {
-		return 0
+	if strings.Contains(origin, "github.com") || strings.Contains(origin, githubHost()) {
+		return origin
 	}
 
-	return len(prs)
+	return "Non-GitHub"
 }
 
-func getRepositoryPRs(repoURL string) ([]PR, error) {
+func getRepositoryPRs(gh *ghSession, repoURL string) ([]PR, error) {
 	if repoURL == "N/A" || repoURL == "Non-GitHub" {
 		return nil, fmt.Errorf("not a GitHub repository")
 	}
 
-	// Check GitHub CLI authentication
-	if !checkGitHubAuth() {
-		return nil, fmt.Errorf("GitHub CLI not authenticated")
+	if err := gh.authError(); err != nil {
+		return nil, err
 	}
 
 	// Extract owner/repo from GitHub URL
-	re := regexp.MustCompile(`https://github\.com/([^/]+)/([^/]+)`)
-	matches := re.FindStringSubmatch(repoURL)
-	if len(matches) != 3 {
+	nameWithOwner, ok := repoNameWithOwner(repoURL)
+	if !ok {
 		return nil, fmt.Errorf("invalid GitHub URL format")
 	}
 
-	owner := matches[1]
-	repo := matches[2]
-
-	// Get current user
-	userCmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	userOutput, err := userCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
-	}
-	currentUser := strings.TrimSpace(string(userOutput))
-
 	// Get PRs for current user with full details
-	prCmd := exec.Command("gh", "pr", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--author", currentUser, "--json", "number,title,url")
-	prOutput, err := prCmd.Output()
+	prOutput, err := gh.run("pr", "list", "--repo", nameWithOwner, "--author", gh.Login, "--json", "number,title,url")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PRs: %w", err)
 	}
@@ -1217,78 +2310,113 @@ func getRepositoryPRs(repoURL string) ([]PR, error) {
 	return prs, nil
 }
 
-func loadAllUserPRs() (*PRCache, error) {
-	// Check GitHub CLI authentication
-	if !checkGitHubAuth() {
-		return &PRCache{
-			allPRs: []PR{},
-			prsByRepo: make(map[string][]PR),
-			loaded: true,
-		}, nil // Return empty cache if not authenticated
-	}
-
-	// Get current user
-	userCmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	userOutput, err := userCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
-	}
-	currentUser := strings.TrimSpace(string(userOutput))
-
-	// Get all PRs by the current user
-	searchCmd := exec.Command("gh", "search", "prs", 
-		"--author", currentUser,
-		"--state", "open", 
-		"--json", "number,title,url,repository",
-		"--limit", "200") // Get up to 200 PRs
-	
-	searchOutput, err := searchCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to search PRs: %w", err)
-	}
-
-	// Parse the search results
-	var searchResults []struct {
-		Number     int    `json:"number"`
-		Title      string `json:"title"`
-		URL        string `json:"url"`
+func loadAllUserPRs(gh *ghSession) (*PRCache, error) {
+	if err := gh.authError(); err != nil {
+		return nil, err
+	}
+	currentUser := gh.Login
+
+	// Get all PRs by the current user through GraphQL search, which unlike
+	// the REST search returns head branches, and reports its own rate limit
+	// separately from the core API
+	type searchResult struct {
+		Number      int    `json:"number"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		HeadRefName string `json:"headRefName"`
+		Body        string `json:"body"`
+		Labels      struct {
+			Nodes []struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"labels"`
 		Repository struct {
-			Name          string `json:"name"`
 			NameWithOwner string `json:"nameWithOwner"`
-			Owner         struct {
-				Login string `json:"login"`
-			} `json:"owner"`
 		} `json:"repository"`
 	}
+	var searchResults []searchResult
 	
-	if err := json.Unmarshal(searchOutput, &searchResults); err != nil {
-		return nil, fmt.Errorf("failed to parse PR search results: %w", err)
+	const perPage, maxPRs = 100, 200 // Get up to 200 PRs
+	// Values go in as variables, never spliced into the query text
+	const query = `query($search: String!, $perPage: Int!, $cursor: String) {
+		search(query: $search, type: ISSUE, first: $perPage, after: $cursor) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				... on PullRequest {
+					number
+					title
+					url
+					headRefName
+					body
+					labels(first: 20) { nodes { name } }
+					repository { nameWithOwner }
+				}
+			}
+		}
+	}`
+	search := fmt.Sprintf("is:pr is:open author:%s", currentUser)
+	cursor := ""
+	for len(searchResults) < maxPRs {
+		args := []string{"graphql", "-f", "query=" + query, "-f", "search=" + search, "-F", fmt.Sprintf("perPage=%d", perPage)}
+		if cursor != "" {
+			args = append(args, "-f", "cursor="+cursor)
+		}
+		searchOutput, err := gh.api(args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search PRs: %w", err)
+		}
+
+		var results struct {
+			Data struct {
+				Search struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []searchResult `json:"nodes"`
+				} `json:"search"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(searchOutput, &results); err != nil {
+			return nil, fmt.Errorf("failed to parse PR search results: %w", err)
+		}
+
+		searchResults = append(searchResults, results.Data.Search.Nodes...)
+		if !results.Data.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor = results.Data.Search.PageInfo.EndCursor
 	}
 
-	// Convert to our PR format and organize by repository
+	// Convert to our PR format
 	var allPRs []PR
-	prsByRepo := make(map[string][]PR)
 	
 	for _, result := range searchResults {
-		repoURL := fmt.Sprintf("https://github.com/%s", result.Repository.NameWithOwner)
+		if result.Repository.NameWithOwner == "" {
+			continue
+		}
+		repoURL := githubRepoURL(result.Repository.NameWithOwner)
 		
 		pr := PR{
 			Number:  result.Number,
 			Title:   result.Title, // Keep original title without [repo] prefix for cache
 			URL:     result.URL,
-			Branch:  "", // Branch info not available in search results
+			Branch:  result.HeadRefName,
+			Body:    result.Body,
 			RepoURL: repoURL,
 		}
+		for _, label := range result.Labels.Nodes {
+			pr.Labels = append(pr.Labels, label.Name)
+		}
 		
 		allPRs = append(allPRs, pr)
-		prsByRepo[repoURL] = append(prsByRepo[repoURL], pr)
 	}
 
-	return &PRCache{
-		allPRs:    allPRs,
-		prsByRepo: prsByRepo,
-		loaded:    true,
-	}, nil
+	cache := newPRCache(allPRs)
+	cache.login = gh.Login
+	cache.host = gh.Host
+	cache.fetchedAt = time.Now()
+	return cache, nil
 }
 
 
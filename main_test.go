@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestExtractWordsProperties(t *testing.T) {
+	property := func(text unicodeText) bool {
+		words := extractWords(string(text))
+		for _, word := range words {
+			if word == "" || strings.IndexFunc(word, func(r rune) bool { return !isWordRune(r) }) >= 0 {
+				t.Logf("extractWords(%q) has word %q", text, word)
+				return false
+			}
+		}
+		// Words are the text with its separators dropped, split up
+		want := strings.Map(func(r rune) rune {
+			if !isWordRune(r) {
+				return -1
+			}
+			return r
+		}, string(text))
+		if got := strings.Join(words, ""); got != want {
+			t.Logf("extractWords(%q) = %q, loses or adds text", text, words)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsWordBoundaryProperties(t *testing.T) {
+	property := func(text unicodeText) bool {
+		runes := []rune(string(text))
+		starts := clusterStarts(string(text))
+		for pos := 0; pos <= len(runes)+1; pos++ {
+			boundary := isWordBoundary(runes, pos)
+			if pos == 0 && !boundary || pos > 0 && pos >= len(runes) && boundary {
+				t.Logf("isWordBoundary(%q, %d) = %v", text, pos, boundary)
+				return false
+			}
+			// Words never split a grapheme cluster
+			if boundary && pos > 0 && isWordRune(runes[pos-1]) && isWordRune(runes[pos]) && !starts[pos] {
+				t.Logf("isWordBoundary(%q, %d) splits a cluster", text, pos)
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMnemonicMatchProperties(t *testing.T) {
+	// Any query matches without panicking, at the offsets of its runes
+	arbitrary := func(text, query unicodeText) bool {
+		_, positions, ok := mnemonicMatch(string(text), string(query))
+		if !ok {
+			return true
+		}
+		q := []rune(string(query))
+		if len(positions) != len(q) || !checkPositions(t, string(text), positions) {
+			t.Logf("mnemonicMatch(%q, %q) = %v", text, query, positions)
+			return false
+		}
+		runes := []rune(string(text))
+		for i, pos := range positions {
+			if unicode.ToLower(runes[pos]) != unicode.ToLower(q[i]) {
+				t.Logf("mnemonicMatch(%q, %q) matched %q to %q", text, query, q[i], runes[pos])
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(arbitrary, nil); err != nil {
+		t.Error(err)
+	}
+
+	// Prefixes of some of the words, in order, always match
+	prefixes := func(text unicodeText, lengths []uint8) bool {
+		runes := []rune(string(text))
+		var query []rune
+		for i, span := range wordSpans(runes) {
+			if i < len(lengths) {
+				n := min(int(lengths[i])%4, span[1]-span[0])
+				query = append(query, runes[span[0]:span[0]+n]...)
+			}
+		}
+		if _, _, ok := mnemonicMatch(string(text), string(query)); !ok {
+			t.Logf("mnemonicMatch(%q, %q) didn't match", text, string(query))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(prefixes, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExtractWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"operations-istio-cni", []string{"operations", "istio", "cni"}},
+		{"fooBar_baz/qux quux", []string{"foo", "Bar", "baz", "qux", "quux"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"getHTTPResponse", []string{"get", "HTTP", "Response"}},
+		{"k8s2", []string{"k", "8", "s", "2"}},
+		{"v1.2", []string{"v", "1", "2"}},
+		{"résumé-über", []string{"résumé", "über"}},
+		{"日本-語", []string{"日本", "語"}},
+		{"--", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := extractWords(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("extractWords(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMnemonicMatch(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        []int // nil when it shouldn't match
+	}{
+		{"operations-istio-cni", "oic", []int{0, 11, 17}},
+		{"operations-istio-cni", "opiscn", []int{0, 1, 11, 12, 17, 18}},
+		{"operations-istio-cni", "OIC", []int{0, 11, 17}},
+		{"operations-istio-cni", "ic", []int{11, 17}},
+		{"HTTPServer", "hs", []int{0, 4}},
+		{"HTTPServer", "httpser", []int{0, 1, 2, 3, 4, 5, 6}},
+		{"k8s2", "k8s2", []int{0, 1, 2, 3}},
+		{"k8s2", "ks", []int{0, 2}},
+		{"über-straße", "üs", []int{0, 5}},
+		{"operations-istio-cni", "oci", nil}, // Out of order
+		{"operations-istio-cni", "opx", nil},
+		{"foobar", "fb", nil}, // b doesn't start a word
+		{"HTTPServer", "ht", []int{0, 1}},
+		{"HTTPServer", "tp", nil},
+	}
+	for _, tt := range tests {
+		_, got, ok := mnemonicMatch(tt.text, tt.query)
+		if ok != (tt.want != nil) || !slices.Equal(got, tt.want) {
+			t.Errorf("mnemonicMatch(%q, %q) = %v, %v, want %v", tt.text, tt.query, got, ok, tt.want)
+		}
+	}
+}
+
+func TestMnemonicMatchScoring(t *testing.T) {
+	// Each query should score better against the first text than the second
+	tests := []struct {
+		query, better, worse string
+	}{
+		{"oic", "operations-istio-cni", "operations-foo-istio-bar-cni"}, // No skipped words
+		{"is", "istio-cni", "in-sync"},                                  // One longer prefix
+	}
+	for _, tt := range tests {
+		better, _, _ := mnemonicMatch(tt.better, tt.query)
+		worse, _, _ := mnemonicMatch(tt.worse, tt.query)
+		if better <= worse {
+			t.Errorf("mnemonicMatch(_, %q) scores %q %d, not above %q %d", tt.query, tt.better, better, tt.worse, worse)
+		}
+	}
+}
+
+func TestConvertToGitHubURL(t *testing.T) {
+	tests := []struct {
+		host, origin, want string
+	}{
+		{"", "git@github.com:acme/web.git", "https://github.com/acme/web"},
+		{"", "ssh://git@github.com/acme/web.git", "https://github.com/acme/web"},
+		{"", "https://github.com/acme/web.git", "https://github.com/acme/web"},
+		{"", "https://github.com/acme/web", "https://github.com/acme/web"},
+		{"", "https://token@github.com/acme/web.git", "https://github.com/acme/web"},
+		{"", "git@gitlab.com:acme/web.git", "Non-GitHub"},
+		{"", "git@ghe.acme.com:acme/web.git", "Non-GitHub"},
+		{"", "N/A", "N/A"},
+		{"", "", "N/A"},
+
+		// An Enterprise host is recognised alongside github.com
+		{"ghe.acme.com", "git@ghe.acme.com:acme/web.git", "https://ghe.acme.com/acme/web"},
+		{"ghe.acme.com", "https://ghe.acme.com/acme/web.git", "https://ghe.acme.com/acme/web"},
+		{"ghe.acme.com", "git@github.com:acme/web.git", "https://github.com/acme/web"},
+		{"ghe.acme.com", "git@gitlab.com:acme/web.git", "Non-GitHub"},
+	}
+	for _, tt := range tests {
+		t.Setenv("GH_HOST", tt.host)
+		if got := convertToGitHubURL(tt.origin); got != tt.want {
+			t.Errorf("with GH_HOST=%q, convertToGitHubURL(%q) = %q, want %q", tt.host, tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestListViewFitsTerminal(t *testing.T) {
+	var repos []GitRepo
+	for i := range 100 {
+		repos = append(repos, GitRepo{Directory: fmt.Sprintf("/src/repo-%d", i), GitHubURL: "N/A"})
+	}
+	for _, width := range []int{40, 80, 120, 400} {
+		m := model{repos: repos, filteredRepos: repos, terminalWidth: width, terminalHeight: 30}
+		// The long footer wraps on narrow terminals, taking rows from the list
+		if got := lipgloss.Height(m.renderListView()); got > m.terminalHeight {
+			t.Errorf("at width %d the list view is %d lines, taller than the terminal's %d", width, got, m.terminalHeight)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// Scoring weights for fuzzyMatch, modelled on fzf's.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	bonusBoundary    = 8 // Match at the start of the text or right after a separator
+	bonusCamel       = 7 // Match on a lowercase-to-uppercase transition
+	bonusConsecutive = 4 // Match continuing a run of matches
+	bonusFirstChar   = 2 // Multiplier for the bonus of the first query character
+
+	bonusBasename = 24 // The query matches within the last path element
+	bonusPrefix   = 32 // The last path element starts with the query
+	bonusExact    = 64 // The last path element is the query
+)
+
+// noMatch marks alignments that aren't possible in fuzzyMatch's table.
+const noMatch = math.MinInt / 2
+
+// fuzzyMatch aligns the query against the text as a case-insensitive
+// subsequence, Smith-Waterman style: every matched character scores,
+// matches on word boundaries and in contiguous runs earn bonuses, and gaps
+// between matches cost. It returns the score of the best alignment and the
+// rune offsets in the text that it matched, or false when the query isn't
+// a subsequence of the text.
+func fuzzyMatch(text, query string) (int, []int, bool) {
+	// Fold case a rune at a time so offsets in the text stay put
+	t := []rune(text)
+	q := []rune(query)
+	for i, r := range q {
+		q[i] = unicode.ToLower(r)
+	}
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	if len(q) > len(t) {
+		return 0, nil, false
+	}
+
+	lower := make([]rune, len(t))
+	bonus := make([]int, len(t))
+	for j, r := range t {
+		lower[j] = unicode.ToLower(r)
+		bonus[j] = charBonus(t, j)
+	}
+
+	// score[i][j] is the best score with query character i matched at text
+	// position j, and from[i][j] where character i-1 was matched on that path
+	score := make([][]int, len(q))
+	from := make([][]int, len(q))
+	for i := range q {
+		score[i] = make([]int, len(t))
+		from[i] = make([]int, len(t))
+	}
+	for j := range t {
+		score[0][j] = noMatch
+		if lower[j] == q[0] {
+			score[0][j] = scoreMatch + bonus[j]*bonusFirstChar
+		}
+	}
+
+	for i := 1; i < len(q); i++ {
+		prev, cur := score[i-1], score[i]
+		// gapped is the best score that reaches j with at least one
+		// unmatched character since the previous match, which was at gappedFrom
+		gapped, gappedFrom := noMatch, -1
+		for j := range t {
+			cur[j] = noMatch
+			if gapped != noMatch {
+				gapped += scoreGapExtension
+			}
+			if j >= 2 && prev[j-2] != noMatch && prev[j-2]+scoreGapStart > gapped {
+				gapped, gappedFrom = prev[j-2]+scoreGapStart, j-2
+			}
+			if j == 0 || lower[j] != q[i] {
+				continue
+			}
+
+			best, bestFrom := noMatch, -1
+			if prev[j-1] != noMatch {
+				best, bestFrom = prev[j-1]+max(bonus[j], bonusConsecutive), j-1
+			}
+			if gapped != noMatch && gapped+bonus[j] > best {
+				best, bestFrom = gapped+bonus[j], gappedFrom
+			}
+			if best != noMatch {
+				cur[j] = best + scoreMatch
+				from[i][j] = bestFrom
+			}
+		}
+	}
+
+	last := len(q) - 1
+	best, end := noMatch, -1
+	for j, s := range score[last] {
+		if s > best {
+			best, end = s, j
+		}
+	}
+	if best == noMatch {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(q))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return best, positions, true
+}
+
+// charBonus is the bonus for matching the character at position j.
+func charBonus(t []rune, j int) int {
+	if j == 0 {
+		return bonusBoundary
+	}
+	switch {
+	case !isWordRune(t[j-1]):
+		return bonusBoundary
+	case isWordBoundary(t, j):
+		// camelCase, acronyms and digits
+		return bonusCamel
+	}
+	return 0
+}
+
+// matchText reports whether the query matches the text the way search has
+// always worked, as a substring or a mnemonic, and if so how well and at
+// which rune offsets. The better of the fuzzy and mnemonic alignments wins.
+func matchText(text, query string) (int, []int, bool) {
+	mnemonicScore, mnemonicPositions, mnemonicOK := mnemonicMatch(text, query)
+	if !mnemonicOK && !strings.Contains(strings.ToLower(text), strings.ToLower(query)) {
+		return 0, nil, false
+	}
+	score, positions, ok := fuzzyMatch(text, query)
+	if mnemonicOK && (!ok || mnemonicScore > score) {
+		return mnemonicScore, mnemonicPositions, true
+	}
+	return score, positions, ok
+}
+
+// matchPath is matchText for a path or URL, preferring matches within its
+// last element and above all ones that start it.
+func matchPath(path, query string) (int, []int, bool) {
+	score, positions, ok := matchText(path, query)
+	if !ok {
+		return 0, nil, false
+	}
+
+	trimmed := strings.TrimRight(path, `/\`)
+	baseStart := strings.LastIndexAny(trimmed, `/\`) + 1
+	base := trimmed[baseStart:]
+	if baseScore, basePositions, ok := fuzzyMatch(base, query); ok && baseScore+bonusBasename > score {
+		score = baseScore + bonusBasename
+		// Offsets are in runes, counted from the start of the path
+		offset := utf8.RuneCountInString(path[:baseStart])
+		positions = positions[:0]
+		for _, pos := range basePositions {
+			positions = append(positions, pos+offset)
+		}
+	}
+	baseLower := strings.ToLower(base)
+	queryLower := strings.ToLower(query)
+	if baseLower == queryLower {
+		score += bonusExact
+	} else if strings.HasPrefix(baseLower, queryLower) {
+		score += bonusPrefix
+	}
+	return score, positions, true
+}
+
+// matchRepo scores a repo against the query by its directory or GitHub URL,
+// whichever matches better. The positions are always within the directory,
+// which is what the list shows, and are nil if only the URL matched.
+func matchRepo(repo GitRepo, query string) (int, []int, bool) {
+	dirScore, dirPositions, dirOK := matchPath(repo.Directory, query)
+	urlScore, _, urlOK := matchPath(repo.GitHubURL, query)
+	switch {
+	case dirOK && urlOK:
+		return max(dirScore, urlScore), dirPositions, true
+	case dirOK:
+		return dirScore, dirPositions, true
+	case urlOK:
+		return urlScore, nil, true
+	}
+	return 0, nil, false
+}
+
+// scoredRepo is a search result waiting to be ranked.
+type scoredRepo struct {
+	repo  GitRepo
+	score int
+}
+
+// rankRepos orders results best first, counting how often and how recently
+// each repo was selected on top of its match score. Equal scores keep
+// discovery order.
+func rankRepos(results []scoredRepo, history selectionHistory) []GitRepo {
+	now := time.Now()
+	for i := range results {
+		results[i].score += history.frecencyBonus(results[i].repo.Directory, now)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	repos := make([]GitRepo, len(results))
+	for i, result := range results {
+		repos[i] = result.repo
+	}
+	return repos
+}
+
+// renderHighlighted styles the runes of text at the given offsets with the
+// highlight style and the rest with the base style, a run at a time. A
+// grapheme cluster is highlighted as a whole when any of its runes is, so
+// accents are never split from their letter.
+func renderHighlighted(text string, positions []int, base, highlight lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var b strings.Builder
+	var run strings.Builder
+	runHighlighted := false
+	offset := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		runes := graphemes.Runes()
+		highlighted := false
+		for i := range runes {
+			highlighted = highlighted || matched[offset+i]
+		}
+		offset += len(runes)
+
+		if highlighted != runHighlighted && run.Len() > 0 {
+			b.WriteString(styleFor(runHighlighted, base, highlight).Render(run.String()))
+			run.Reset()
+		}
+		runHighlighted = highlighted
+		run.WriteString(graphemes.Str())
+	}
+	if run.Len() > 0 {
+		b.WriteString(styleFor(runHighlighted, base, highlight).Render(run.String()))
+	}
+	return b.String()
+}
+
+func styleFor(highlighted bool, base, highlight lipgloss.Style) lipgloss.Style {
+	if highlighted {
+		return highlight
+	}
+	return base
+}
+
+// shiftPositions re-bases match offsets onto a substring of the text that
+// starts at rune offset start and is length runes long, dropping the ones
+// outside it.
+func shiftPositions(positions []int, start, length int) []int {
+	var shifted []int
+	for _, pos := range positions {
+		if pos >= start && pos < start+length {
+			shifted = append(shifted, pos-start)
+		}
+	}
+	return shifted
+}
+
+// clipGraphemes keeps the first n user-perceived characters of s.
+func clipGraphemes(s string, n int) string {
+	end := 0
+	state := -1
+	for rest := s; rest != "" && n > 0; n-- {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		end += len(cluster)
+	}
+	return s[:end]
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// textPieces are the building blocks of generated text: separators, cased
+// ASCII, digits, precomposed and decomposed accents, scripts without case,
+// and multi-rune grapheme clusters such as emoji sequences and flags.
+var textPieces = []string{
+	"a", "b", "o", "Z", "K", "s", "8", "2", "-", "_", "/", ".", " ", ":",
+	"\u00e9", "e\u0301", "\u0301", "\u00df", "\u0130", "\u03a3", "\u03c2", "\u0436", "\u0416",
+	"\u65e5\u672c", "\ud55c", "\u1100\u1161\u11a8", "\u0915\u094d\u0937", "\u0627", "\u0663",
+	"\U0001f44d", "\U0001f44d\U0001f3fd", "\U0001f469\u200d\U0001f4bb", "\U0001f1ef\U0001f1f5", "1\ufe0f\u20e3", "\u200d", "\ufe0f",
+}
+
+// unicodeText is arbitrary text for testing/quick, mixing the pieces above
+// with random runes from anywhere in Unicode.
+type unicodeText string
+
+func (unicodeText) Generate(r *rand.Rand, size int) reflect.Value {
+	var b strings.Builder
+	for range r.Intn(size + 1) {
+		if r.Intn(4) == 0 {
+			b.WriteRune(rune(r.Intn(utf8.MaxRune + 1)))
+			continue
+		}
+		b.WriteString(textPieces[r.Intn(len(textPieces))])
+	}
+	return reflect.ValueOf(unicodeText(b.String()))
+}
+
+// clusterStarts returns the rune offsets where grapheme clusters of s
+// start, plus its end.
+func clusterStarts(s string) map[int]bool {
+	starts := map[int]bool{0: true}
+	offset := 0
+	graphemes := uniseg.NewGraphemes(s)
+	for graphemes.Next() {
+		offset += len(graphemes.Runes())
+		starts[offset] = true
+	}
+	return starts
+}
+
+// checkPositions reports whether positions are strictly increasing rune
+// offsets into text.
+func checkPositions(t *testing.T, text string, positions []int) bool {
+	t.Helper()
+	n := utf8.RuneCountInString(text)
+	for i, pos := range positions {
+		if pos < 0 || pos >= n || i > 0 && pos <= positions[i-1] {
+			t.Logf("positions %v out of order or outside the %d runes of %q", positions, n, text)
+			return false
+		}
+	}
+	return true
+}
+
+func TestFuzzyMatchProperties(t *testing.T) {
+	property := func(text unicodeText, mask uint64) bool {
+		runes := []rune(string(text))
+		// A subsequence of the text always matches, case aside
+		var query []rune
+		for i, r := range runes {
+			if mask&(1<<(i%64)) != 0 {
+				query = append(query, r)
+			}
+		}
+		_, positions, ok := fuzzyMatch(string(text), string(query))
+		if !ok || len(positions) != len(query) && len(query) > 0 {
+			t.Logf("%q didn't match %q", string(query), text)
+			return false
+		}
+		return checkPositions(t, string(text), positions)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClipGraphemesProperties(t *testing.T) {
+	property := func(text unicodeText, n int8) bool {
+		s := string(text)
+		clipped := clipGraphemes(s, int(n))
+		if !strings.HasPrefix(s, clipped) {
+			t.Logf("%q isn't a prefix of %q", clipped, s)
+			return false
+		}
+		want := min(max(int(n), 0), uniseg.GraphemeClusterCount(s))
+		if got := uniseg.GraphemeClusterCount(clipped); got != want {
+			t.Logf("clipGraphemes(%q, %d) = %q, %d clusters, want %d", s, n, clipped, got, want)
+			return false
+		}
+		// The cut never falls inside a cluster
+		if !clusterStarts(s)[utf8.RuneCountInString(clipped)] {
+			t.Logf("clipGraphemes(%q, %d) = %q splits a cluster", s, n, clipped)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Markers around highlighted runs, from the private use area, which
+// generated text never contains.
+const (
+	highlightStart = '\ue000'
+	highlightEnd   = '\ue001'
+)
+
+func TestRenderHighlightedProperties(t *testing.T) {
+	highlight := lipgloss.NewStyle().Transform(func(s string) string {
+		return string(highlightStart) + s + string(highlightEnd)
+	})
+	property := func(text unicodeText, offsets []int16) bool {
+		s := strings.Map(func(r rune) rune {
+			// Rows are single lines without markers of their own
+			if r == highlightStart || r == highlightEnd || r < ' ' || r == 0x7f {
+				return -1
+			}
+			return r
+		}, string(text))
+		n := utf8.RuneCountInString(s)
+		var positions []int
+		matched := make(map[int]bool)
+		for _, offset := range offsets {
+			// Mostly within the text, sometimes past either end
+			pos := int(offset) % (n + 2)
+			positions = append(positions, pos)
+			matched[pos] = true
+		}
+
+		rendered := renderHighlighted(s, positions, lipgloss.NewStyle(), highlight)
+
+		// Recover which runes were highlighted
+		var plain []rune
+		var highlighted []bool
+		inside := false
+		for _, r := range rendered {
+			switch r {
+			case highlightStart:
+				inside = true
+			case highlightEnd:
+				inside = false
+			default:
+				plain = append(plain, r)
+				highlighted = append(highlighted, inside)
+			}
+		}
+		if string(plain) != s {
+			t.Logf("renderHighlighted changed %q to %q", s, string(plain))
+			return false
+		}
+
+		// Every cluster is highlighted exactly when one of its runes matched
+		offset := 0
+		graphemes := uniseg.NewGraphemes(s)
+		for graphemes.Next() {
+			size := len(graphemes.Runes())
+			want := false
+			for i := range size {
+				want = want || matched[offset+i]
+			}
+			for i := range size {
+				if highlighted[offset+i] != want {
+					t.Logf("cluster %q at %d of %q highlighted %v, want %v", graphemes.Str(), offset, s, highlighted[offset+i], want)
+					return false
+				}
+			}
+			offset += size
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		text, query string
+		ok          bool
+	}{
+		{"qgh", "", true},
+		{"qgh", "qgh", true},
+		{"QGH", "qgh", true},
+		{"qgh", "QGH", true},
+		{"quick-git-helper", "qgh", true},
+		{"qgh", "qghx", false},
+		{"qgh", "hgq", false},
+		{"résumé", "rsm", true},
+		{"", "a", false},
+	}
+	for _, tt := range tests {
+		if _, _, ok := fuzzyMatch(tt.text, tt.query); ok != tt.ok {
+			t.Errorf("fuzzyMatch(%q, %q) ok = %v, want %v", tt.text, tt.query, ok, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchScoring(t *testing.T) {
+	// Each query should score better against the first text than the second
+	tests := []struct {
+		query, better, worse string
+	}{
+		{"abc", "abc", "axbxc"},           // Contiguous run
+		{"bar", "foo-bar", "foobar"},      // Word boundary
+		{"bar", "fooBar", "foobar"},       // camelCase hump
+		{"cni", "istio-cni", "icon-nine"}, // Run on a boundary over scattered hits
+		{"ab", "axb", "axxxb"},            // Shorter gap
+	}
+	for _, tt := range tests {
+		better, _, _ := fuzzyMatch(tt.better, tt.query)
+		worse, _, _ := fuzzyMatch(tt.worse, tt.query)
+		if better <= worse {
+			t.Errorf("fuzzyMatch(_, %q) scores %q %d, not above %q %d", tt.query, tt.better, better, tt.worse, worse)
+		}
+	}
+}
+
+func TestMatchText(t *testing.T) {
+	tests := []struct {
+		text, query string
+		ok          bool
+	}{
+		{"foobar", "oba", true},                  // Substring
+		{"operations-istio-cni", "oic", true},    // Mnemonic
+		{"operations-istio-cni", "opiscn", true}, // Mnemonic with longer prefixes
+		{"foobar", "fbr", false},                 // A subsequence alone isn't enough
+		{"foobar", "", true},
+	}
+	for _, tt := range tests {
+		if _, _, ok := matchText(tt.text, tt.query); ok != tt.ok {
+			t.Errorf("matchText(%q, %q) ok = %v, want %v", tt.text, tt.query, ok, tt.ok)
+		}
+	}
+}
+
+func TestRankRepos(t *testing.T) {
+	tests := []struct {
+		query string
+		paths []string
+		want  []string
+	}{
+		{
+			// Exact basename, then basename prefix, then anywhere in the
+			// basename, then elsewhere in the path
+			query: "qgh",
+			paths: []string{"/src/qgh/docs", "/src/tools/quick-git-helper", "/src/qgh-tools", "/src/qgh"},
+			want:  []string{"/src/qgh", "/src/qgh-tools", "/src/tools/quick-git-helper", "/src/qgh/docs"},
+		},
+		{
+			query: "api",
+			paths: []string{"/src/api/web", "/src/web/api"},
+			want:  []string{"/src/web/api", "/src/api/web"},
+		},
+		{
+			// Equal scores keep discovery order
+			query: "svc",
+			paths: []string{"/a/svc", "/b/svc", "/c/svc"},
+			want:  []string{"/a/svc", "/b/svc", "/c/svc"},
+		},
+	}
+	for _, tt := range tests {
+		var results []scoredRepo
+		for _, path := range tt.paths {
+			if score, _, ok := matchPath(path, tt.query); ok {
+				results = append(results, scoredRepo{repo: GitRepo{Directory: path}, score: score})
+			}
+		}
+		var got []string
+		for _, repo := range rankRepos(results, nil) {
+			got = append(got, repo.Directory)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ranking %v for %q = %v, want %v", tt.paths, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPositions(t *testing.T) {
+	tests := []struct {
+		name        string
+		match       func(text, query string) (int, []int, bool)
+		text, query string
+		want        []int
+	}{
+		{"fuzzy", fuzzyMatch, "operations-istio-cni", "oic", []int{0, 11, 17}},
+		{"fuzzy", fuzzyMatch, "foo-bar", "bar", []int{4, 5, 6}},
+		{"text", matchText, "operations-istio-cni", "opiscn", []int{0, 1, 11, 12, 17, 18}},
+		{"path", matchPath, "/src/foo/qgh", "qgh", []int{9, 10, 11}},
+		{"path", matchPath, "/src/qgh/qgh", "qgh", []int{9, 10, 11}},
+		{"path", matchPath, "/src/über/qgh", "qgh", []int{10, 11, 12}}, // Runes, not bytes
+		{"path", matchPath, "/src/qgh/", "qgh", []int{5, 6, 7}},
+	}
+	for _, tt := range tests {
+		_, got, ok := tt.match(tt.text, tt.query)
+		if !ok || !slices.Equal(got, tt.want) {
+			t.Errorf("%s match of %q in %q = %v, %v, want %v", tt.name, tt.query, tt.text, got, ok, tt.want)
+		}
+	}
+}
+
+func TestMatchRepoPositions(t *testing.T) {
+	repo := GitRepo{Directory: "/src/web", GitHubURL: "https://github.com/acme/frontend"}
+	if _, positions, ok := matchRepo(repo, "web"); !ok || !slices.Equal(positions, []int{5, 6, 7}) {
+		t.Errorf("matchRepo on the directory = %v, %v, want [5 6 7]", positions, ok)
+	}
+	// Nothing to highlight when only the URL matched
+	if _, positions, ok := matchRepo(repo, "frontend"); !ok || positions != nil {
+		t.Errorf("matchRepo on the URL = %v, %v, want no positions", positions, ok)
+	}
+}
+
+func TestShiftPositions(t *testing.T) {
+	tests := []struct {
+		positions     []int
+		start, length int
+		want          []int
+	}{
+		{[]int{1, 3, 5, 7}, 3, 4, []int{0, 2}},
+		{[]int{0, 1, 2}, 0, 3, []int{0, 1, 2}},
+		{[]int{0, 1, 2}, 3, 5, nil},
+		{nil, 0, 10, nil},
+	}
+	for _, tt := range tests {
+		if got := shiftPositions(tt.positions, tt.start, tt.length); !slices.Equal(got, tt.want) {
+			t.Errorf("shiftPositions(%v, %d, %d) = %v, want %v", tt.positions, tt.start, tt.length, got, tt.want)
+		}
+	}
+}
+
+func TestRenderHighlighted(t *testing.T) {
+	highlight := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	tests := []struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		{"qgh", nil, "qgh"},
+		{"foo-bar", []int{4, 5, 6}, "foo-[bar]"},
+		{"foo-bar", []int{0, 4}, "[f]oo-[b]ar"},
+		{"e\u0301tude", []int{1}, "[e\u0301]tude"}, // The accent takes its letter along
+		{"qgh", []int{5}, "qgh"},
+	}
+	for _, tt := range tests {
+		if got := renderHighlighted(tt.text, tt.positions, lipgloss.NewStyle(), highlight); got != tt.want {
+			t.Errorf("renderHighlighted(%q, %v) = %q, want %q", tt.text, tt.positions, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notificationReasons are the unread notifications worth surfacing, with the
+// label shown for each.
+var notificationReasons = map[string]string{
+	"review_requested": "review",
+	"mention":          "mention",
+	"team_mention":     "mention",
+	"ci_activity":      "CI",
+}
+
+type Notification struct {
+	ID        string
+	Reason    string
+	Type      string // Subject type: PullRequest, Issue, CheckSuite, ...
+	Title     string
+	URL       string // Browser URL of the thread
+	RepoURL   string // GitHub repository URL this notification belongs to
+	UpdatedAt time.Time
+}
+
+// The notifications API returns at most 50 threads a page; the inbox reads
+// up to notificationMaxPages of them.
+const (
+	notificationPageSize = 50
+	notificationMaxPages = 10
+)
+
+type notificationsLoadedMsg struct {
+	notifications []Notification
+	truncated     bool // More unread threads than were fetched
+	err           error
+}
+
+type notificationMarkedMsg struct {
+	id  string
+	err error
+}
+
+func loadNotificationsCmd(gh *ghSession) tea.Cmd {
+	return func() tea.Msg {
+		notifications, truncated, err := fetchNotifications(gh)
+		return notificationsLoadedMsg{notifications: notifications, truncated: truncated, err: err}
+	}
+}
+
+func markNotificationReadCmd(gh *ghSession, id string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := gh.api("-X", "PATCH", "notifications/threads/"+id)
+		return notificationMarkedMsg{id: id, err: err}
+	}
+}
+
+// fetchNotifications reads the unread threads page by page, reporting
+// whether there were more than notificationMaxPages of them.
+func fetchNotifications(gh *ghSession) ([]Notification, bool, error) {
+	if err := gh.authError(); err != nil {
+		return nil, false, err
+	}
+	if !gh.hasScope("notifications") && !gh.hasScope("repo") {
+		return nil, false, fmt.Errorf("token lacks the notifications scope, run 'gh auth refresh -s notifications'")
+	}
+
+	var notifications []Notification
+	for page := 1; page <= notificationMaxPages; page++ {
+		output, err := gh.api("-X", "GET", "notifications",
+			"-f", fmt.Sprintf("per_page=%d", notificationPageSize), "-f", fmt.Sprintf("page=%d", page))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load notifications: %w", err)
+		}
+
+		wanted, threads, err := parseNotifications(output)
+		if err != nil {
+			return nil, false, err
+		}
+		notifications = append(notifications, wanted...)
+		if threads < notificationPageSize {
+			return notifications, false, nil
+		}
+	}
+	return notifications, true, nil
+}
+
+// parseNotifications picks the notifications worth surfacing out of a page
+// of threads, also returning how many threads the page had.
+func parseNotifications(output []byte) ([]Notification, int, error) {
+	var threads []struct {
+		ID        string    `json:"id"`
+		Reason    string    `json:"reason"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Subject   struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+			Type  string `json:"type"`
+		} `json:"subject"`
+		Repository struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(output, &threads); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse notifications: %w", err)
+	}
+
+	var notifications []Notification
+	for _, thread := range threads {
+		if _, wanted := notificationReasons[thread.Reason]; !wanted {
+			continue
+		}
+		if thread.Reason == "ci_activity" && !isCIFailure(thread.Subject.Title) {
+			// Passing and cancelled runs notify too; only failures need you
+			continue
+		}
+		notifications = append(notifications, Notification{
+			ID:        thread.ID,
+			Reason:    thread.Reason,
+			Type:      thread.Subject.Type,
+			Title:     thread.Subject.Title,
+			URL:       threadBrowserURL(thread.Subject.URL, thread.Repository.HTMLURL, thread.Subject.Type),
+			RepoURL:   thread.Repository.HTMLURL,
+			UpdatedAt: thread.UpdatedAt,
+		})
+	}
+	return notifications, len(threads), nil
+}
+
+// isCIFailure reports whether a CI notification is about a failed run. Its
+// subject only says so in the title, e.g. "CI workflow run failed for main
+// branch".
+func isCIFailure(title string) bool {
+	title = strings.ToLower(title)
+	return strings.Contains(title, " failed") || strings.Contains(title, " timed out")
+}
+
+// threadBrowserURL turns a notification's API subject URL into the page a
+// browser should open. Subjects without a page of their own, such as CI
+// runs, open the matching tab of the repository instead.
+func threadBrowserURL(subjectURL, repoURL, subjectType string) string {
+	// The part after repos/<owner>/<name>/, e.g. "pulls/12"
+	var kind, id string
+	if _, path, found := strings.Cut(subjectURL, "/repos/"); found {
+		if parts := strings.SplitN(path, "/", 3); len(parts) == 3 {
+			kind, id, _ = strings.Cut(parts[2], "/")
+		}
+	}
+
+	switch subjectType {
+	case "PullRequest":
+		if kind == "pulls" && id != "" {
+			return repoURL + "/pull/" + id
+		}
+		return repoURL + "/pulls"
+	case "Issue":
+		if kind == "issues" && id != "" {
+			return repoURL + "/issues/" + id
+		}
+		return repoURL + "/issues"
+	case "Commit":
+		if kind == "commits" && id != "" {
+			return repoURL + "/commit/" + id
+		}
+		return repoURL + "/commits"
+	case "Discussion":
+		if kind == "discussions" && id != "" {
+			return repoURL + "/discussions/" + id
+		}
+		return repoURL + "/discussions"
+	case "Release":
+		// The API names releases by ID, which has no page; the tag would
+		// take another request
+		return repoURL + "/releases"
+	case "CheckSuite":
+		return repoURL + "/actions"
+	}
+	return repoURL
+}
+
+func (s *ghSession) hasScope(scope string) bool {
+	for _, granted := range s.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// localRepoFor finds the checked-out repository for a GitHub URL, the same
+// way prsByRepo keys PRs by repository.
+func (m model) localRepoFor(repoURL string) *GitRepo {
+	for i := range m.repos {
+		if m.repos[i].GitHubURL == repoURL {
+			return &m.repos[i]
+		}
+	}
+	return nil
+}
+
+// openNotificationsView switches to the notifications inbox, remembering the
+// view to return to.
+func (m model) openNotificationsView() (model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = notificationsView
+	m.notificationCursor = 0
+	m.notificationScrollOffset = 0
+
+	if !m.notificationsLoading {
+		m.startJob(jobNotifications)
+	}
+	if m.gh == nil {
+		// Session not established yet; the inbox loads once it is
+		m.notificationsLoading = true
+		return m, nil
+	}
+	m.notificationsLoading = true
+	m.notificationsErr = nil
+	m.notificationsTruncated = false
+	return m, loadNotificationsCmd(m.gh)
+}
+
+// notificationsHelp is the key help under the inbox.
+const notificationsHelp = "Use ↑/↓ to navigate, Enter to open thread, M to mark read, Ctrl+D to cd to repo and exit, Ctrl+N to reload, Esc to go back, Ctrl+C to quit"
+
+func (m model) notificationsVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + scroll indicators(2) + newline before footer(1) + status bar(1) = 7 lines, and the footer
+	visibleHeight := m.terminalHeight - 7 - m.footerHeight(notificationsHelp)
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+	return visibleHeight
+}
+
+func (m model) updateNotificationsView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.currentView = m.previousView
+	case "ctrl+n":
+		// Reload the inbox
+		return m.openNotificationsView()
+	case "ctrl+d":
+		if m.notificationCursor < len(m.notifications) {
+			if repo := m.localRepoFor(m.notifications[m.notificationCursor].RepoURL); repo != nil {
+				// Save the selection before quitting
+				return m, tea.Sequence(m.recordSelection(repo.Directory), changeDirCmd(repo.Directory))
+			}
+		}
+	case "enter":
+		if m.notificationCursor < len(m.notifications) {
+			return m, m.openInBrowser(m.notifications[m.notificationCursor].URL)
+		}
+	case "m", "M":
+		if m.notificationCursor < len(m.notifications) {
+			m.startJob(jobMarkRead)
+			return m, markNotificationReadCmd(m.gh, m.notifications[m.notificationCursor].ID)
+		}
+	case "up":
+		if m.notificationCursor > 0 {
+			m.notificationCursor--
+			if m.notificationCursor < m.notificationScrollOffset {
+				m.notificationScrollOffset = m.notificationCursor
+			}
+		}
+	case "down":
+		if m.notificationCursor < len(m.notifications)-1 {
+			m.notificationCursor++
+			visibleHeight := m.notificationsVisibleHeight()
+			if m.notificationCursor >= m.notificationScrollOffset+visibleHeight {
+				m.notificationScrollOffset = m.notificationCursor - visibleHeight + 1
+			}
+		}
+	}
+	return m, nil
+}
+
+// removeNotification drops a thread that was marked read, keeping the cursor
+// on a valid row.
+func (m *model) removeNotification(id string) {
+	for i, notification := range m.notifications {
+		if notification.ID == id {
+			m.notifications = append(m.notifications[:i], m.notifications[i+1:]...)
+			break
+		}
+	}
+	m.clampNotificationCursor()
+}
+
+func (m *model) clampNotificationCursor() {
+	if m.notificationCursor >= len(m.notifications) {
+		m.notificationCursor = len(m.notifications) - 1
+	}
+	if m.notificationCursor < 0 {
+		m.notificationCursor = 0
+	}
+	if m.notificationScrollOffset > m.notificationCursor {
+		m.notificationScrollOffset = m.notificationCursor
+	}
+}
+
+func (m model) renderNotificationsView() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("62")).
+		Foreground(lipgloss.Color("230"))
+
+	reasonStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("14"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9"))
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Notifications (%d unread)", len(m.notifications))))
+	if m.notificationsTruncated {
+		b.WriteString(dimStyle.Render(fmt.Sprintf(" (only the latest %d threads were read)", notificationPageSize*notificationMaxPages)))
+	}
+	b.WriteString(m.renderGitHubIdentity())
+	b.WriteString("\n\n")
+
+	visibleHeight := m.notificationsVisibleHeight()
+
+	// Show local repos by the same minimal paths as the list view
+	minPaths := calculateMinimalPaths(m.repos)
+	pathByDir := make(map[string]string, len(m.repos))
+	for i, repo := range m.repos {
+		pathByDir[repo.Directory] = minPaths[i]
+	}
+
+	switch {
+	case m.notificationsLoading:
+		b.WriteString("Loading notifications...\n")
+	case m.notificationsErr != nil:
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.notificationsErr)))
+		b.WriteString("\n")
+	case len(m.notifications) == 0:
+		b.WriteString("No unread review requests, mentions or CI failures.\n")
+	default:
+		if m.notificationScrollOffset > 0 {
+			b.WriteString("↑ (more above)\n")
+		} else {
+			b.WriteString("\n")
+		}
+
+		endIdx := min(m.notificationScrollOffset+visibleHeight, len(m.notifications))
+		for i := m.notificationScrollOffset; i < endIdx; i++ {
+			notification := m.notifications[i]
+
+			repoLabel, _ := repoNameWithOwner(notification.RepoURL)
+			if repo := m.localRepoFor(notification.RepoURL); repo != nil {
+				repoLabel = pathByDir[repo.Directory]
+			} else {
+				repoLabel = dimStyle.Render(repoLabel + " (not cloned)")
+			}
+
+			line := fmt.Sprintf("%-8s %s  %s", notificationReasons[notification.Reason], notification.Title, repoLabel)
+			if i == m.notificationCursor {
+				line = selectedStyle.Render(line)
+			} else {
+				line = reasonStyle.Render(fmt.Sprintf("%-8s", notificationReasons[notification.Reason])) +
+					fmt.Sprintf(" %s  %s", notification.Title, repoLabel)
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+
+		if endIdx < len(m.notifications) {
+			b.WriteString("↓ (more below)\n")
+		} else {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(notificationsHelp))
+
+	return b.String()
+}
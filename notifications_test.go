@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestParseNotifications(t *testing.T) {
+	page := `[
+		{"id": "1", "reason": "review_requested", "subject": {"title": "Fix login", "url": "https://api.github.com/repos/acme/web/pulls/7", "type": "PullRequest"}, "repository": {"html_url": "https://github.com/acme/web"}},
+		{"id": "2", "reason": "subscribed", "subject": {"title": "Release v2", "type": "Release"}, "repository": {"html_url": "https://github.com/acme/web"}},
+		{"id": "3", "reason": "ci_activity", "subject": {"title": "CI workflow run succeeded for main branch", "type": "CheckSuite"}, "repository": {"html_url": "https://github.com/acme/web"}},
+		{"id": "4", "reason": "ci_activity", "subject": {"title": "CI workflow run failed for main branch", "type": "CheckSuite"}, "repository": {"html_url": "https://github.com/acme/web"}}
+	]`
+	notifications, threads, err := parseNotifications([]byte(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every thread counts towards the page, wanted or not
+	if threads != 4 {
+		t.Errorf("threads = %d, want 4", threads)
+	}
+	if len(notifications) != 2 || notifications[0].ID != "1" || notifications[1].ID != "4" {
+		t.Fatalf("notifications = %+v, want threads 1 and 4", notifications)
+	}
+	if got := notifications[0].URL; got != "https://github.com/acme/web/pull/7" {
+		t.Errorf("URL = %q, want the pull request page", got)
+	}
+
+	if _, _, err := parseNotifications([]byte(`{"message": "Not Found"}`)); err == nil {
+		t.Error("parseNotifications accepted an object")
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	prCacheFile = "pr-cache.json"
+
+	// prCacheTTL is how long a persisted PR cache is used without asking
+	// GitHub. Older caches are still shown while a refresh runs.
+	prCacheTTL = 5 * time.Minute
+)
+
+// persistedPRCache is the on-disk form of PRCache.
+type persistedPRCache struct {
+	Login     string    `json:"login"`
+	Host      string    `json:"host"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	PRs       []PR      `json:"prs"`
+}
+
+// loadPersistedPRCache returns the PR cache saved by the last run, or nil
+// if there isn't a usable one.
+func loadPersistedPRCache() *PRCache {
+	path, err := cachePath(prCacheFile)
+	if err != nil {
+		return nil
+	}
+
+	var persisted persistedPRCache
+	if err := readJSONFile(path, &persisted); err != nil || persisted.FetchedAt.IsZero() {
+		return nil
+	}
+
+	cache := newPRCache(persisted.PRs)
+	cache.login = persisted.Login
+	cache.host = persisted.Host
+	cache.fetchedAt = persisted.FetchedAt
+	return cache
+}
+
+func savePRCache(cache *PRCache) error {
+	path, err := cachePath(prCacheFile)
+	if err != nil {
+		return fmt.Errorf("failed to locate PR cache: %w", err)
+	}
+	return writeJSONFile(path, persistedPRCache{
+		Login:     cache.login,
+		Host:      cache.host,
+		FetchedAt: cache.fetchedAt,
+		PRs:       cache.allPRs,
+	})
+}
+
+// newPRCache indexes PRs by repository.
+func newPRCache(prs []PR) *PRCache {
+	prsByRepo := make(map[string][]PR)
+	for _, pr := range prs {
+		prsByRepo[pr.RepoURL] = append(prsByRepo[pr.RepoURL], pr)
+	}
+	return &PRCache{
+		allPRs:    prs,
+		prsByRepo: prsByRepo,
+		loaded:    true,
+	}
+}
+
+// cachedPRs returns your open PRs in a repo, or nil until the cache loads.
+func (m *model) cachedPRs(repoURL string) []PR {
+	if m.prCache == nil || !m.prCache.loaded {
+		return nil
+	}
+	return m.prCache.prsByRepo[repoURL]
+}
+
+// belongsTo reports whether the cache was fetched for this GitHub identity.
+func (c *PRCache) belongsTo(gh *ghSession) bool {
+	return c.login == gh.Login && c.host == gh.Host
+}
+
+// stale reports whether the cache should be refreshed from GitHub.
+func (c *PRCache) stale() bool {
+	return c.fetchedAt.IsZero() || time.Since(c.fetchedAt) > prCacheTTL
+}
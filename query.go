@@ -0,0 +1,551 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// queryFields are the qualifiers a search term can start with, as
+// `field:value`.
+var queryFields = map[string]bool{
+	"owner":      true, // Owner of the origin repository
+	"host":       true, // Host of the origin remote
+	"prs":        true, // Number of your open PRs, e.g. prs:>0
+	"dirty":      true, // Uncommitted changes, true or false
+	"branch":     true, // Checked-out branch, * globs allowed
+	"lang":       true, // Primary language on GitHub
+	"topic":      true, // GitHub topic
+	"archived":   true, // Archived on GitHub, true or false
+	"visibility": true, // public, private or internal
+	"repo":       true, // owner/name or name, * globs allowed
+	"label":      true, // Label on one of your open PRs
+	"body":       true, // Text in the description of one of your open PRs
+}
+
+// queryFieldAliases are alternative spellings of query fields.
+var queryFieldAliases = map[string]string{
+	"language": "lang",
+}
+
+// queryTerm is one space-separated term of a search. A term is either free
+// text, matched like the search always has been, or a field qualifier.
+type queryTerm struct {
+	negate bool   // Written as !term: the term must not match
+	field  string // Qualifier, "" for free text
+	value  string // Free text as typed; qualifier values are lowercased
+	op     string // Comparison for prs: "=", ">", ">=", "<" or "<="
+	number int    // Operand for prs
+}
+
+// searchQuery is a parsed search. Every term has to hold for a repo to
+// match.
+type searchQuery struct {
+	terms []queryTerm
+	regex *regexp.Regexp // Set in regex mode, where the whole search is one pattern
+
+	// Qualifiers such as "owner:" typed in regex mode, which are matched as
+	// part of the pattern rather than filtering
+	textQualifiers []string
+}
+
+// parseQuery splits a search into terms, reporting the first one that
+// can't be understood.
+func parseQuery(input string) (searchQuery, error) {
+	var query searchQuery
+	for _, word := range strings.Fields(input) {
+		term := queryTerm{}
+		if rest, found := strings.CutPrefix(word, "!"); found {
+			if rest == "" {
+				return searchQuery{}, fmt.Errorf("! needs a term to exclude")
+			}
+			term.negate = true
+			word = rest
+		}
+
+		key, value, found := strings.Cut(word, ":")
+		field := strings.ToLower(key)
+		if alias, ok := queryFieldAliases[field]; ok {
+			field = alias
+		}
+		switch {
+		case !found || strings.HasPrefix(value, "/"):
+			// Free text, including URLs such as https://github.com/...
+			term.value = word
+		case queryFields[field]:
+			if err := term.parseQualifier(field, value); err != nil {
+				return searchQuery{}, err
+			}
+		case isQueryFieldName(key):
+			return searchQuery{}, fmt.Errorf("unknown filter %s:", key)
+		default:
+			term.value = word
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// parseRegexQuery compiles a search typed in regex mode as a single RE2
+// pattern, without qualifiers. Words that would be qualifiers outside regex
+// mode are noted, so the search box can say they don't filter.
+func parseRegexQuery(input string) (searchQuery, error) {
+	re, err := regexp.Compile(input)
+	if err != nil {
+		return searchQuery{}, fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	query := searchQuery{regex: re}
+	for _, word := range strings.Fields(input) {
+		key, _, found := strings.Cut(strings.TrimPrefix(word, "!"), ":")
+		field := strings.ToLower(key)
+		if alias, ok := queryFieldAliases[field]; ok {
+			field = alias
+		}
+		if found && queryFields[field] && !slices.Contains(query.textQualifiers, key+":") {
+			query.textQualifiers = append(query.textQualifiers, key+":")
+		}
+	}
+	return query, nil
+}
+
+// matchRegex reports whether the pattern matches the text, with the rune
+// offsets of every match for highlighting. Regex matches all score the same,
+// leaving the order to selection history.
+func matchRegex(re *regexp.Regexp, text string) (int, []int, bool) {
+	matches := re.FindAllStringIndex(text, -1)
+	if matches == nil {
+		return 0, nil, false
+	}
+	var positions []int
+	offset, runeOffset := 0, 0
+	for _, match := range matches {
+		runeOffset += utf8.RuneCountInString(text[offset:match[0]])
+		for range utf8.RuneCountInString(text[match[0]:match[1]]) {
+			positions = append(positions, runeOffset)
+			runeOffset++
+		}
+		offset = match[1]
+	}
+	return 0, positions, true
+}
+
+// matchRegexPath is matchRegex for a path or URL. Failing the whole path,
+// the pattern is tried against the last element alone, so that anchors
+// work on repository names: ^ops-.*-helm$ matches /src/ops-redis-helm.
+func matchRegexPath(re *regexp.Regexp, path string) (int, []int, bool) {
+	if score, positions, ok := matchRegex(re, path); ok {
+		return score, positions, true
+	}
+	trimmed := strings.TrimRight(path, `/\`)
+	baseStart := strings.LastIndexAny(trimmed, `/\`) + 1
+	score, positions, ok := matchRegex(re, trimmed[baseStart:])
+	if !ok {
+		return 0, nil, false
+	}
+	offset := utf8.RuneCountInString(path[:baseStart])
+	for i := range positions {
+		positions[i] += offset
+	}
+	return score, positions, true
+}
+
+// isQueryFieldName reports whether a word before a colon looks like it was
+// meant as a qualifier rather than part of free text.
+func isQueryFieldName(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *queryTerm) parseQualifier(field, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s: needs a value", field)
+	}
+	t.field = field
+	t.value = strings.ToLower(value)
+
+	switch field {
+	case "prs":
+		t.op = "="
+		number := value
+		for _, op := range []string{">=", "<=", ">", "<", "="} {
+			if rest, found := strings.CutPrefix(value, op); found {
+				t.op, number = op, rest
+				break
+			}
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return fmt.Errorf("prs: needs a number, e.g. prs:>0")
+		}
+		t.number = n
+	case "dirty", "archived":
+		if t.value != "true" && t.value != "false" {
+			return fmt.Errorf("%s: needs true or false", field)
+		}
+	case "visibility":
+		if t.value != "public" && t.value != "private" && t.value != "internal" {
+			return fmt.Errorf("visibility: needs public, private or internal")
+		}
+	case "branch":
+		// Branch names are case-sensitive
+		t.value = value
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("branch: invalid pattern %q", value)
+		}
+	case "repo":
+		if _, err := path.Match(t.value, ""); err != nil {
+			return fmt.Errorf("repo: invalid pattern %q", value)
+		}
+	}
+	return nil
+}
+
+// usesLocalStatus reports whether evaluating the query needs each repo's
+// working tree state.
+func (q searchQuery) usesLocalStatus() bool {
+	for _, term := range q.terms {
+		if term.field == "dirty" || term.field == "branch" {
+			return true
+		}
+	}
+	return false
+}
+
+// freeText returns the free text terms that must match and those that must
+// not.
+func (q searchQuery) freeText() (include, exclude []string) {
+	for _, term := range q.terms {
+		if term.field != "" {
+			continue
+		}
+		if term.negate {
+			exclude = append(exclude, term.value)
+		} else {
+			include = append(include, term.value)
+		}
+	}
+	return include, exclude
+}
+
+// matchText matches the free text terms against text with matchText,
+// adding up the scores and collecting the positions of every term.
+func (q searchQuery) matchText(text string) (int, []int, bool) {
+	if q.regex != nil {
+		return matchRegex(q.regex, text)
+	}
+	return q.matchFreeText([]string{text}, func(term string) (int, []int, bool) {
+		return matchText(text, term)
+	})
+}
+
+// matchPath is matchText for a path or name, using matchPath.
+func (q searchQuery) matchPath(text string) (int, []int, bool) {
+	if q.regex != nil {
+		return matchRegexPath(q.regex, text)
+	}
+	return q.matchFreeText([]string{text}, func(term string) (int, []int, bool) {
+		return matchPath(text, term)
+	})
+}
+
+// matchRepo is matchText for a repo's directory and URL.
+func (q searchQuery) matchRepo(repo GitRepo) (int, []int, bool) {
+	if q.regex != nil {
+		if _, positions, ok := matchRegexPath(q.regex, repo.Directory); ok {
+			return 0, positions, true
+		}
+		_, _, ok := matchRegexPath(q.regex, repo.GitHubURL)
+		return 0, nil, ok
+	}
+	return q.matchFreeText([]string{repo.Directory, repo.GitHubURL}, func(term string) (int, []int, bool) {
+		return matchRepo(repo, term)
+	})
+}
+
+// matchFreeText matches every included term with match, and rejects the
+// result if an excluded term appears in any of fields. Exclusions only
+// match substrings: a mnemonic match would hide results that merely have
+// the term's letters at the start of a few words.
+func (q searchQuery) matchFreeText(fields []string, match func(term string) (int, []int, bool)) (int, []int, bool) {
+	include, exclude := q.freeText()
+	for _, term := range exclude {
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), strings.ToLower(term)) {
+				return 0, nil, false
+			}
+		}
+	}
+
+	total := 0
+	seen := make(map[int]bool)
+	var positions []int
+	for _, term := range include {
+		score, termPositions, ok := match(term)
+		if !ok {
+			return 0, nil, false
+		}
+		total += score
+		for _, pos := range termPositions {
+			if !seen[pos] {
+				seen[pos] = true
+				positions = append(positions, pos)
+			}
+		}
+	}
+	sort.Ints(positions)
+	return total, positions, true
+}
+
+// matchPR is matchText for PR mode, where each term can match the PR's
+// title, #number, head branch, labels or repository name. Positions are
+// within the title; the notes say which other fields matched, e.g.
+// "branch fix/login".
+func (q searchQuery) matchPR(pr PR) (score int, positions []int, notes []string, ok bool) {
+	if q.regex != nil {
+		// Patterns only apply to titles
+		score, positions, ok = matchRegex(q.regex, pr.Title)
+		return score, positions, nil, ok
+	}
+	score, positions, ok = q.matchFreeText(prFields(pr), func(term string) (int, []int, bool) {
+		score, positions, note, ok := matchPRTerm(pr, term)
+		if ok && note != "" && !slices.Contains(notes, note) {
+			notes = append(notes, note)
+		}
+		return score, positions, ok
+	})
+	if !ok {
+		return 0, nil, nil, false
+	}
+	return score, positions, notes, true
+}
+
+// prFields are the PR fields that free text terms can match.
+func prFields(pr PR) []string {
+	fields := []string{pr.Title, fmt.Sprintf("#%d", pr.Number), pr.Branch}
+	fields = append(fields, pr.Labels...)
+	if nameWithOwner, found := repoNameWithOwner(pr.RepoURL); found {
+		fields = append(fields, nameWithOwner)
+	}
+	return fields
+}
+
+// matchPRTerm matches one term against every field of a PR, returning the
+// best match. The title wins ties, and its matches need no note.
+func matchPRTerm(pr PR, term string) (score int, titlePositions []int, note string, ok bool) {
+	score, titlePositions, ok = matchText(pr.Title, term)
+
+	try := func(fieldScore int, fieldOK bool, fieldNote string) {
+		if fieldOK && (!ok || fieldScore > score) {
+			score, titlePositions, note, ok = fieldScore, nil, fieldNote, true
+		}
+	}
+	if digits := strings.TrimPrefix(term, "#"); digits == strconv.Itoa(pr.Number) {
+		try(scoreMatch*len(digits)+bonusExact, true, fmt.Sprintf("#%d", pr.Number))
+	}
+	if pr.Branch != "" {
+		branchScore, _, branchOK := matchPath(pr.Branch, term)
+		try(branchScore, branchOK, "branch "+pr.Branch)
+	}
+	for _, label := range pr.Labels {
+		labelScore, _, labelOK := matchText(label, term)
+		try(labelScore, labelOK, "label "+label)
+	}
+	if nameWithOwner, found := repoNameWithOwner(pr.RepoURL); found {
+		repoScore, _, repoOK := matchPath(nameWithOwner, term)
+		try(repoScore, repoOK, "repo "+nameWithOwner)
+	}
+	return score, titlePositions, note, ok
+}
+
+// matchedPRFields lists the kinds of field other than the title, such as
+// "branch" or "label", that the search matched across PRs.
+func matchedPRFields(prs []PR) []string {
+	var fields []string
+	for _, pr := range prs {
+		for _, note := range pr.MatchNotes {
+			field, _, _ := strings.Cut(note, " ")
+			if strings.HasPrefix(field, "#") {
+				field = "number"
+			}
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// matchesFields reports whether a repo satisfies every qualifier, given your
+// open PRs in it. Qualifiers on data that hasn't loaded yet don't match,
+// except archived:false.
+func (q searchQuery) matchesFields(repo GitRepo, prs []PR, local map[string]localStatus) bool {
+	for _, term := range q.terms {
+		if term.field == "" {
+			continue
+		}
+		if term.matchesRepo(repo, prs, local) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesPRFields reports whether a PR satisfies the qualifiers that apply
+// to single PRs, label: and body:.
+func (q searchQuery) matchesPRFields(pr PR) bool {
+	for _, term := range q.terms {
+		if term.field != "label" && term.field != "body" {
+			continue
+		}
+		if term.matchesPR(pr) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t queryTerm) matchesPR(pr PR) bool {
+	switch t.field {
+	case "label":
+		for _, label := range pr.Labels {
+			if strings.ToLower(label) == t.value {
+				return true
+			}
+		}
+	case "body":
+		return strings.Contains(strings.ToLower(pr.Body), t.value)
+	}
+	return false
+}
+
+func (t queryTerm) matchesRepo(repo GitRepo, prs []PR, local map[string]localStatus) bool {
+	switch t.field {
+	case "repo":
+		nameWithOwner, ok := repoNameWithOwner(repo.GitHubURL)
+		if !ok {
+			return false
+		}
+		nameWithOwner = strings.ToLower(nameWithOwner)
+		if !strings.Contains(t.value, "/") {
+			// Just the name, under any owner
+			_, nameWithOwner, _ = strings.Cut(nameWithOwner, "/")
+		}
+		matched, _ := path.Match(t.value, nameWithOwner)
+		return matched
+	case "label", "body":
+		// A repo matches through any of your PRs in it
+		for _, pr := range prs {
+			if t.matchesPR(pr) {
+				return true
+			}
+		}
+		return false
+	case "owner":
+		owner, ok := repoOwner(repo)
+		return ok && strings.ToLower(owner) == t.value
+	case "host":
+		host, ok := originHost(repo)
+		return ok && strings.ToLower(host) == t.value
+	case "prs":
+		switch t.op {
+		case ">":
+			return repo.PRCount > t.number
+		case ">=":
+			return repo.PRCount >= t.number
+		case "<":
+			return repo.PRCount < t.number
+		case "<=":
+			return repo.PRCount <= t.number
+		}
+		return repo.PRCount == t.number
+	case "dirty":
+		status, ok := local[repo.Directory]
+		return ok && status.Err == nil && strconv.FormatBool(status.Dirty) == t.value
+	case "branch":
+		status, ok := local[repo.Directory]
+		if !ok || status.Branch == "" {
+			return false
+		}
+		matched, _ := path.Match(t.value, status.Branch)
+		return matched
+	}
+
+	// The rest come from GitHub metadata
+	if repo.Meta == nil {
+		return t.field == "archived" && t.value == "false"
+	}
+	switch t.field {
+	case "lang":
+		return strings.ToLower(repo.Meta.Language) == t.value
+	case "topic":
+		for _, topic := range repo.Meta.Topics {
+			if strings.ToLower(topic) == t.value {
+				return true
+			}
+		}
+		return false
+	case "archived":
+		return strconv.FormatBool(repo.Meta.Archived) == t.value
+	case "visibility":
+		return repo.Meta.Visibility == t.value
+	}
+	return false
+}
+
+// repoOwner is the owner of a repo's origin, from its GitHub URL or, for
+// other hosts, the first element of the origin's path.
+func repoOwner(repo GitRepo) (string, bool) {
+	if nameWithOwner, ok := repoNameWithOwner(repo.GitHubURL); ok {
+		owner, _, _ := strings.Cut(nameWithOwner, "/")
+		return owner, true
+	}
+	_, repoPath, ok := parseOrigin(repo.Origin)
+	if !ok {
+		return "", false
+	}
+	owner, _, found := strings.Cut(repoPath, "/")
+	return owner, found
+}
+
+// originHost is the host of a repo's origin remote.
+func originHost(repo GitRepo) (string, bool) {
+	host, _, ok := parseOrigin(repo.Origin)
+	return host, ok
+}
+
+// parseOrigin splits a remote URL, either URL-style or scp-style
+// (git@host:owner/repo.git), into its host and path.
+func parseOrigin(origin string) (host, repoPath string, ok bool) {
+	if origin == "" || origin == "N/A" {
+		return "", "", false
+	}
+	if strings.Contains(origin, "://") {
+		u, err := url.Parse(origin)
+		if err != nil || u.Hostname() == "" {
+			return "", "", false
+		}
+		return u.Hostname(), strings.Trim(u.Path, "/"), true
+	}
+	hostPart, repoPath, found := strings.Cut(origin, ":")
+	if !found {
+		// A local path
+		return "", "", false
+	}
+	if _, after, found := strings.Cut(hostPart, "@"); found {
+		hostPart = after
+	}
+	return hostPart, strings.Trim(repoPath, "/"), true
+}
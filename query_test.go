@@ -0,0 +1,277 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []queryTerm
+		err   string
+	}{
+		{input: "", want: nil},
+		{input: "  istio   cni ", want: []queryTerm{{value: "istio"}, {value: "cni"}}},
+		{input: "!helm", want: []queryTerm{{negate: true, value: "helm"}}},
+		{input: "Owner:Acme", want: []queryTerm{{field: "owner", value: "acme"}}},
+		{input: "language:Go", want: []queryTerm{{field: "lang", value: "go"}}},
+		{input: "!dirty:true", want: []queryTerm{{negate: true, field: "dirty", value: "true"}}},
+		{input: "branch:Fix/*", want: []queryTerm{{field: "branch", value: "Fix/*"}}},
+		{input: "prs:3", want: []queryTerm{{field: "prs", value: "3", op: "=", number: 3}}},
+		{input: "prs:>0", want: []queryTerm{{field: "prs", value: ">0", op: ">", number: 0}}},
+		{input: "prs:<=2", want: []queryTerm{{field: "prs", value: "<=2", op: "<=", number: 2}}},
+		{input: "visibility:Private", want: []queryTerm{{field: "visibility", value: "private"}}},
+		{input: "label:Bug", want: []queryTerm{{field: "label", value: "bug"}}},
+		{input: "repo:acme/*-helm", want: []queryTerm{{field: "repo", value: "acme/*-helm"}}},
+
+		// Free text that only looks like a qualifier
+		{input: "https://github.com/acme/web", want: []queryTerm{{value: "https://github.com/acme/web"}}},
+		{input: "v1:2", want: []queryTerm{{value: "v1:2"}}},
+		{input: ":x", want: []queryTerm{{value: ":x"}}},
+
+		{input: "!", err: "! needs a term to exclude"},
+		{input: "web !", err: "! needs a term to exclude"},
+		{input: "owner:", err: "owner: needs a value"},
+		{input: "prs:many", err: "prs: needs a number, e.g. prs:>0"},
+		{input: "prs:>", err: "prs: needs a number, e.g. prs:>0"},
+		{input: "dirty:yes", err: "dirty: needs true or false"},
+		{input: "archived:1", err: "archived: needs true or false"},
+		{input: "visibility:secret", err: "visibility: needs public, private or internal"},
+		{input: "branch:[", err: `branch: invalid pattern "["`},
+		{input: "repo:[", err: `repo: invalid pattern "["`},
+		{input: "label:", err: "label: needs a value"},
+		{input: "stars:>10", err: "unknown filter stars:"},
+	}
+	for _, tt := range tests {
+		query, err := parseQuery(tt.input)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("parseQuery(%q) error = %v, want %q", tt.input, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseQuery(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(query.terms, tt.want) {
+			t.Errorf("parseQuery(%q) = %+v, want %+v", tt.input, query.terms, tt.want)
+		}
+	}
+}
+
+func TestMatchesFields(t *testing.T) {
+	repo := GitRepo{
+		Directory: "/src/web",
+		Origin:    "git@github.com:Acme/web.git",
+		GitHubURL: "https://github.com/Acme/web",
+		PRCount:   2,
+	}
+	local := map[string]localStatus{"/src/web": {Branch: "fix/login", Dirty: true}}
+	prs := []PR{{Number: 7, Title: "Fix login", Labels: []string{"Bug"}, Body: "Closes the redirect loop"}}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"owner:acme", true},
+		{"owner:other", false},
+		{"host:github.com", true},
+		{"prs:2", true},
+		{"prs:>2", false},
+		{"prs:>=2", true},
+		{"prs:<3", true},
+		{"dirty:true", true},
+		{"!dirty:true", false},
+		{"branch:fix/*", true},
+		{"branch:Fix/*", false}, // Branch names are case-sensitive
+		{"repo:web", true},
+		{"repo:acme/w*", true},
+		{"repo:other/web", false},
+		{"label:bug", true},
+		{"label:feature", false},
+		{"body:redirect", true},
+		{"body:timeout", false},
+		{"owner:acme prs:>0 dirty:true", true},
+		{"owner:acme !label:bug", false},
+		{"web", true}, // Free text is matched elsewhere
+
+		// GitHub metadata hasn't loaded
+		{"lang:go", false},
+		{"archived:false", true},
+		{"archived:true", false},
+	}
+	for _, tt := range tests {
+		query, err := parseQuery(tt.input)
+		if err != nil {
+			t.Fatalf("parseQuery(%q) error = %v", tt.input, err)
+		}
+		if got := query.matchesFields(repo, prs, local); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPR(t *testing.T) {
+	pr := PR{
+		Number:  123,
+		Title:   "Fix bug in login redirect",
+		Branch:  "fix/login",
+		Labels:  []string{"bug", "needs-review"},
+		RepoURL: "https://github.com/acme/web",
+	}
+	tests := []struct {
+		input     string
+		ok        bool
+		positions []int
+		notes     []string
+	}{
+		{"redirect", true, []int{17, 18, 19, 20, 21, 22, 23, 24}, nil},
+		{"bug", true, []int{4, 5, 6}, nil}, // The title wins a tie with the label
+		{"#123", true, nil, []string{"#123"}},
+		{"123", true, nil, []string{"#123"}},
+		{"login", true, nil, []string{"branch fix/login"}},
+		{"needs", true, nil, []string{"label needs-review"}},
+		{"acme/web", true, nil, []string{"repo acme/web"}},
+		{"#123 needs", true, nil, []string{"#123", "label needs-review"}},
+		{"redirect needs", true, []int{17, 18, 19, 20, 21, 22, 23, 24}, []string{"label needs-review"}},
+		{"#124", false, nil, nil},
+		{"redirect !needs", false, nil, nil},
+		{"redirect !lr", true, []int{17, 18, 19, 20, 21, 22, 23, 24}, nil}, // Exclusions aren't mnemonics
+		{"redirect !#123", false, nil, nil},
+		{"deploy", false, nil, nil},
+	}
+	for _, tt := range tests {
+		query, err := parseQuery(tt.input)
+		if err != nil {
+			t.Fatalf("parseQuery(%q) error = %v", tt.input, err)
+		}
+		_, positions, notes, ok := query.matchPR(pr)
+		if ok != tt.ok || !slices.Equal(positions, tt.positions) || !slices.Equal(notes, tt.notes) {
+			t.Errorf("matchPR for %q = %v, %v, %q, want %v, %v, %q", tt.input, ok, positions, notes, tt.ok, tt.positions, tt.notes)
+		}
+	}
+}
+
+func TestMatchTextExclusions(t *testing.T) {
+	tests := []struct {
+		input, text string
+		want        bool
+	}{
+		{"!cni", "operations-istio-cni", false},
+		{"!CNI", "operations-istio-cni", false},
+		{"!oic", "operations-istio-cni", true}, // Only a mnemonic match
+		{"!opc", "operations-istio-cni", true}, // Only a fuzzy match
+		{"istio !helm", "operations-istio-cni", true},
+		{"istio !cni", "operations-istio-cni", false},
+	}
+	for _, tt := range tests {
+		query, err := parseQuery(tt.input)
+		if err != nil {
+			t.Fatalf("parseQuery(%q) error = %v", tt.input, err)
+		}
+		if _, _, ok := query.matchText(tt.text); ok != tt.want {
+			t.Errorf("%q against %q = %v, want %v", tt.input, tt.text, ok, tt.want)
+		}
+	}
+}
+
+func TestMatchedPRFields(t *testing.T) {
+	prs := []PR{
+		{MatchNotes: []string{"#123", "label bug"}},
+		{MatchNotes: []string{"branch fix/login", "label needs-review"}},
+		{},
+	}
+	want := []string{"number", "label", "branch"}
+	if got := matchedPRFields(prs); !slices.Equal(got, want) {
+		t.Errorf("matchedPRFields = %q, want %q", got, want)
+	}
+}
+
+func TestParseRegexQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{input: "^ops-.*-helm$"},
+		{input: "(?i)istio|cni"},
+		{input: "owner:acme"}, // No qualifiers in regex mode
+		{input: "ops-(", err: "missing closing ): `ops-(`"},
+		{input: "[z-a]", err: "invalid character class range: `z-a`"},
+		{input: "*", err: "missing argument to repetition operator: `*`"},
+	}
+	for _, tt := range tests {
+		query, err := parseRegexQuery(tt.input)
+		switch {
+		case tt.err != "" && (err == nil || err.Error() != tt.err):
+			t.Errorf("parseRegexQuery(%q) error = %v, want %q", tt.input, err, tt.err)
+		case tt.err == "" && (err != nil || query.regex == nil || query.terms != nil):
+			t.Errorf("parseRegexQuery(%q) = %+v, %v", tt.input, query, err)
+		}
+	}
+}
+
+func TestRegexTextQualifiers(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"^ops-.*-helm$", nil},
+		{"owner:acme", []string{"owner:"}},
+		{"!Owner:acme language:go owner:x", []string{"Owner:", "language:", "owner:"}},
+		{"https://github.com/acme", nil},
+		{"v1:2", nil},
+	}
+	for _, tt := range tests {
+		query, err := parseRegexQuery(tt.input)
+		if err != nil {
+			t.Fatalf("parseRegexQuery(%q) error = %v", tt.input, err)
+		}
+		if !slices.Equal(query.textQualifiers, tt.want) {
+			t.Errorf("parseRegexQuery(%q) notes qualifiers %q, want %q", tt.input, query.textQualifiers, tt.want)
+		}
+	}
+}
+
+func TestMatchRegexPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          []int // nil when it shouldn't match
+	}{
+		{"^ops-.*-helm$", "/src/ops-redis-helm", []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18}},
+		{"^ops-.*-helm$", "/src/ops-redis-helm/", []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18}},
+		{"^ops-.*-helm$", `C:\src\ops-redis-helm`, []int{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}},
+		{"^ops-.*-helm$", "/src/ops-redis-helm-old", nil},
+		{"^/src", "/src/web", []int{0, 1, 2, 3}},
+		{"b", "/ü/ä/b", []int{5}}, // Runes, not bytes
+		{"e", "/src/web-e", []int{6, 9}},
+		{"x", "/src/web", nil},
+	}
+	for _, tt := range tests {
+		query, err := parseRegexQuery(tt.pattern)
+		if err != nil {
+			t.Fatalf("parseRegexQuery(%q) error = %v", tt.pattern, err)
+		}
+		_, got, ok := query.matchPath(tt.path)
+		if ok != (tt.want != nil) || !slices.Equal(got, tt.want) {
+			t.Errorf("%q against %q = %v, %v, want %v", tt.pattern, tt.path, got, ok, tt.want)
+		}
+	}
+}
+
+func TestMatchRegexPRTitle(t *testing.T) {
+	query, err := parseRegexQuery("^Fix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Patterns only apply to titles, with no notes
+	_, positions, notes, ok := query.matchPR(PR{Title: "Fix login", Branch: "fix/login"})
+	if !ok || !slices.Equal(positions, []int{0, 1, 2}) || notes != nil {
+		t.Errorf("matchPR = %v, %v, %q, want [0 1 2] and no notes", ok, positions, notes)
+	}
+	if _, _, _, ok := query.matchPR(PR{Title: "Refactor", Branch: "Fix/login"}); ok {
+		t.Error("matchPR matched the branch of a PR")
+	}
+}
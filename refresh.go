@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultRefreshInterval is how often the PR cache is refreshed while qgh
+// stays open, unless the config says otherwise.
+const defaultRefreshInterval = 10 * time.Minute
+
+type refreshTickMsg struct{}
+
+func refreshTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// refresh reloads the PR cache, and the issue cache if it's been loaded,
+// unless a refresh is already running or there's no one to ask GitHub as.
+// Working tree state is reread too once it's been read.
+func (m model) refresh() (model, tea.Cmd) {
+	cmds := []tea.Cmd{m.reloadLocalStatus()}
+	if m.prCacheRefreshing || !m.gh.authenticated() {
+		return m, tea.Batch(cmds...)
+	}
+	m.prCacheRefreshing = true
+	m.startJob(jobPRCache)
+	cmds = append(cmds, loadPRCacheCmd(m.gh))
+	if m.issueCache != nil && m.issueCache.loaded {
+		m.startJob(jobIssues)
+		cmds = append(cmds, loadIssueCacheCmd(m.gh))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// refilterKeepingPosition re-applies the search after background data
+// changes, keeping the cursor on the same repo and the list where it was.
+func (m *model) refilterKeepingPosition() {
+	var selectedDir string
+	if m.cursor < len(m.filteredRepos) {
+		selectedDir = m.filteredRepos[m.cursor].Directory
+	}
+	scrollOffset := m.scrollOffset
+
+	m.filterRepos()
+
+	for i, repo := range m.filteredRepos {
+		if repo.Directory == selectedDir {
+			m.cursor = i
+			break
+		}
+	}
+
+	// Keep the previous scroll position as long as the cursor stays visible
+	visibleHeight := m.listVisibleHeight()
+	m.scrollOffset = scrollOffset
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleHeight {
+		m.scrollOffset = m.cursor - visibleHeight + 1
+	}
+
+	// The detail view shows PRs copied out of the cache; pick up the new ones
+	if m.currentView == detailView && m.selectedRepo != nil && !m.startedInDetailView && m.prCache != nil {
+		m.repoDetails = m.prCache.prsByRepo[m.selectedRepo.GitHubURL]
+		if m.issueCache != nil && m.issueCache.loaded {
+			m.repoIssues = m.issueCache.issuesByRepo[m.selectedRepo.GitHubURL]
+		}
+		if maxItems := m.detailItemCount(); m.detailCursor >= maxItems {
+			m.detailCursor = maxItems - 1
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// releaseScanConcurrency bounds how many repos are inspected with git at once.
+const releaseScanConcurrency = 8
+
+// releaseStatus is what's known locally about a repo's release state.
+type releaseStatus struct {
+	DefaultBranch     string // Remote-tracking ref, e.g. origin/main
+	LastTag           string // Most recent tag reachable from DefaultBranch
+	UnreleasedCommits int    // Commits on DefaultBranch since LastTag, -1 if unknown
+}
+
+// ReleaseInfo backs the releases section of the detail view.
+type ReleaseInfo struct {
+	releaseStatus
+	LatestRelease    string
+	LatestReleaseURL string
+	PublishedAt      time.Time
+	UnpushedTags     []string
+	UnpushedTagsErr  error // Why origin's tags couldn't be listed
+}
+
+type unreleasedCountsMsg struct {
+	statuses map[string]releaseStatus // Keyed by repo directory
+}
+
+type releaseInfoLoadedMsg struct {
+	repoURL string
+	info    *ReleaseInfo
+	err     error
+}
+
+// loadUnreleasedCountsCmd works out the unreleased commits column for the
+// given repos in the background, a few repos at a time.
+func loadUnreleasedCountsCmd(repos []GitRepo) tea.Cmd {
+	return func() tea.Msg {
+		statuses := make(map[string]releaseStatus, len(repos))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, releaseScanConcurrency)
+
+		for _, repo := range repos {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(dir string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				status := getReleaseStatus(dir)
+				mu.Lock()
+				statuses[dir] = status
+				mu.Unlock()
+			}(repo.Directory)
+		}
+		wg.Wait()
+
+		return unreleasedCountsMsg{statuses: statuses}
+	}
+}
+
+// loadVisibleReleaseStatus works out the unreleased commits column for the
+// rows on screen that haven't been scanned yet, so startup doesn't run git
+// in every repo.
+func (m *model) loadVisibleReleaseStatus() tea.Cmd {
+	if m.currentView != listView {
+		return nil
+	}
+	// Same rows as renderListView shows
+	visibleHeight := m.listVisibleHeight()
+	var repos []GitRepo
+	end := min(m.scrollOffset+visibleHeight, len(m.filteredRepos))
+	for i := m.scrollOffset; i < end; i++ {
+		repo := m.filteredRepos[i]
+		if repo.RemoteOnly || m.releaseStatusPending[repo.Directory] {
+			continue
+		}
+		if _, ok := m.releaseStatuses[repo.Directory]; ok {
+			continue
+		}
+		repos = append(repos, repo)
+	}
+	if len(repos) == 0 {
+		return nil
+	}
+
+	if m.releaseStatusPending == nil {
+		m.releaseStatusPending = make(map[string]bool)
+	}
+	for _, repo := range repos {
+		m.releaseStatusPending[repo.Directory] = true
+	}
+	m.startJob(jobReleaseScan)
+	return loadUnreleasedCountsCmd(repos)
+}
+
+// mergeReleaseStatuses records freshly scanned release state.
+func (m *model) mergeReleaseStatuses(statuses map[string]releaseStatus) {
+	if m.releaseStatuses == nil {
+		m.releaseStatuses = make(map[string]releaseStatus, len(statuses))
+	}
+	for dir, status := range statuses {
+		m.releaseStatuses[dir] = status
+		delete(m.releaseStatusPending, dir)
+	}
+}
+
+func loadReleaseInfoCmd(gh *ghSession, repo GitRepo) tea.Cmd {
+	return func() tea.Msg {
+		info, err := fetchReleaseInfo(gh, repo)
+		return releaseInfoLoadedMsg{repoURL: repo.GitHubURL, info: info, err: err}
+	}
+}
+
+// getReleaseStatus counts the commits on the default branch since its last
+// tag, using only local refs so it stays fast across hundreds of repos.
+func getReleaseStatus(repoDir string) releaseStatus {
+	status := releaseStatus{UnreleasedCommits: -1}
+
+	status.DefaultBranch = getDefaultBranchRef(repoDir)
+	if status.DefaultBranch == "" {
+		return status
+	}
+
+	tagCmd := exec.Command("git", "-C", repoDir, "describe", "--tags", "--abbrev=0", status.DefaultBranch)
+	tagOutput, err := tagCmd.Output()
+	if err != nil {
+		// No tags yet: everything on the branch is unreleased
+		if count, err := countCommits(repoDir, status.DefaultBranch); err == nil {
+			status.UnreleasedCommits = count
+		}
+		return status
+	}
+	status.LastTag = strings.TrimSpace(string(tagOutput))
+
+	if count, err := countCommits(repoDir, status.LastTag+".."+status.DefaultBranch); err == nil {
+		status.UnreleasedCommits = count
+	}
+	return status
+}
+
+// getDefaultBranchRef returns the remote-tracking ref of the default branch,
+// falling back to origin/main and origin/master when origin/HEAD isn't set.
+func getDefaultBranchRef(repoDir string) string {
+	if branch := getDefaultBranch(repoDir); branch != "" {
+		return "origin/" + branch
+	}
+	for _, ref := range []string{"origin/main", "origin/master"} {
+		cmd := exec.Command("git", "-C", repoDir, "rev-parse", "--verify", "--quiet", ref)
+		if cmd.Run() == nil {
+			return ref
+		}
+	}
+	return ""
+}
+
+func countCommits(repoDir, revRange string) (int, error) {
+	cmd := exec.Command("git", "-C", repoDir, "rev-list", "--count", revRange)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(output)))
+}
+
+// getUnpushedTags lists local tags that the origin remote doesn't have.
+func getUnpushedTags(repoDir string) ([]string, error) {
+	localOutput, err := exec.Command("git", "-C", repoDir, "tag", "--list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	// Never wait on a password or passphrase prompt the TUI would hide
+	cmd := exec.Command("git", "-C", repoDir, "ls-remote", "--tags", "--refs", "origin")
+	cmd.Env = nonInteractiveGitEnv()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	remoteOutput, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote tags: %s", ghErrorText(err, stderr.String()))
+	}
+	remoteTags := make(map[string]bool)
+	for _, line := range strings.Split(string(remoteOutput), "\n") {
+		if _, ref, found := strings.Cut(line, "\t"); found {
+			remoteTags[strings.TrimPrefix(ref, "refs/tags/")] = true
+		}
+	}
+
+	var unpushed []string
+	for _, tag := range strings.Fields(string(localOutput)) {
+		if !remoteTags[tag] {
+			unpushed = append(unpushed, tag)
+		}
+	}
+	return unpushed, nil
+}
+
+// nonInteractiveGitEnv is the environment for git commands that talk to a
+// remote: they fail instead of prompting for credentials or host keys.
+func nonInteractiveGitEnv() []string {
+	sshCommand := os.Getenv("GIT_SSH_COMMAND")
+	if sshCommand == "" {
+		sshCommand = "ssh"
+	}
+	return append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_SSH_COMMAND="+sshCommand+" -o BatchMode=yes")
+}
+
+// fetchReleaseInfo reads a repo's tags and asks GitHub for its latest
+// release. When origin or GitHub can't be asked, the local information is
+// still returned, along with the reason.
+func fetchReleaseInfo(gh *ghSession, repo GitRepo) (*ReleaseInfo, error) {
+	info := &ReleaseInfo{releaseStatus: getReleaseStatus(repo.Directory)}
+	info.UnpushedTags, info.UnpushedTagsErr = getUnpushedTags(repo.Directory)
+
+	if err := gh.authError(); err != nil {
+		return info, err
+	}
+	nameWithOwner, ok := repoNameWithOwner(repo.GitHubURL)
+	if !ok {
+		return info, fmt.Errorf("not a GitHub repository")
+	}
+
+	output, err := gh.api("repos/" + nameWithOwner + "/releases/latest")
+	if err != nil {
+		if errors.Is(err, errGHNotFound) {
+			// The repository has no releases yet
+			return info, nil
+		}
+		return info, fmt.Errorf("failed to get latest release: %w", err)
+	}
+
+	var release struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+	}
+	if err := json.Unmarshal(output, &release); err != nil {
+		return info, fmt.Errorf("failed to parse release: %w", err)
+	}
+	info.LatestRelease = release.TagName
+	if release.Name != "" && release.Name != release.TagName {
+		info.LatestRelease = fmt.Sprintf("%s (%s)", release.TagName, release.Name)
+	}
+	info.LatestReleaseURL = release.HTMLURL
+	info.PublishedAt = release.PublishedAt
+
+	return info, nil
+}
+
+// releaseLines renders the releases section as selectable rows. The first
+// row is the latest release, which Enter opens, or why it's unknown.
+func (info *ReleaseInfo) releaseLines(releaseErr string) []string {
+	var lines []string
+
+	if releaseErr != "" {
+		lines = append(lines, "Latest release: unavailable, "+releaseErr)
+	} else if info.LatestRelease == "" {
+		lines = append(lines, "Latest release: none")
+	} else {
+		lines = append(lines, fmt.Sprintf("Latest release: %s, published %s ago", info.LatestRelease, formatAge(time.Since(info.PublishedAt))))
+	}
+
+	switch {
+	case info.DefaultBranch == "":
+		lines = append(lines, "Unreleased commits: unknown (no origin default branch)")
+	case info.LastTag == "":
+		lines = append(lines, fmt.Sprintf("Unreleased commits: %d on %s, no tags yet", info.UnreleasedCommits, info.DefaultBranch))
+	default:
+		lines = append(lines, fmt.Sprintf("Unreleased commits: %d on %s since %s", info.UnreleasedCommits, info.DefaultBranch, info.LastTag))
+	}
+
+	if info.UnpushedTagsErr != nil {
+		lines = append(lines, "Unpushed tags: unknown, "+info.UnpushedTagsErr.Error())
+	} else if len(info.UnpushedTags) == 0 {
+		lines = append(lines, "Unpushed tags: none")
+	}
+	for _, tag := range info.UnpushedTags {
+		lines = append(lines, "Unpushed tag: "+tag)
+	}
+	return lines
+}
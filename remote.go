@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	remoteReposCacheFile = "remote-repos.json"
+	remoteReposTTL       = time.Hour
+)
+
+// remoteRepo is an org repository as listed by the GitHub API.
+type remoteRepo struct {
+	NameWithOwner string `json:"nameWithOwner"`
+	Archived      bool   `json:"archived"`
+}
+
+// remoteReposCache is the on-disk listing of each configured org.
+type remoteReposCache struct {
+	FetchedAt time.Time    `json:"fetchedAt"`
+	Orgs      []string     `json:"orgs"`
+	Repos     []remoteRepo `json:"repos"`
+}
+
+type remoteReposLoadedMsg struct {
+	repos []remoteRepo
+	err   error
+}
+
+type repoClonedMsg struct {
+	repo   GitRepo
+	thenCd bool // Change into the clone once it's done
+	err    error
+}
+
+func loadRemoteReposCmd(gh *ghSession, orgs []string) tea.Cmd {
+	return func() tea.Msg {
+		repos, err := loadRemoteRepos(gh, orgs)
+		return remoteReposLoadedMsg{repos: repos, err: err}
+	}
+}
+
+// loadRemoteRepos lists the repositories of every configured org, served
+// from the cache while it's younger than remoteReposTTL.
+func loadRemoteRepos(gh *ghSession, orgs []string) ([]remoteRepo, error) {
+	path, err := cachePath(remoteReposCacheFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate remote repo cache: %w", err)
+	}
+
+	var cached remoteReposCache
+	if err := readJSONFile(path, &cached); err == nil &&
+		time.Since(cached.FetchedAt) < remoteReposTTL &&
+		strings.Join(cached.Orgs, ",") == strings.Join(orgs, ",") {
+		return cached.Repos, nil
+	}
+
+	if err := gh.authError(); err != nil {
+		return nil, err
+	}
+
+	var repos []remoteRepo
+	for _, org := range orgs {
+		orgRepos, err := fetchOrgRepos(gh, org)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, orgRepos...)
+	}
+
+	cached = remoteReposCache{FetchedAt: time.Now(), Orgs: orgs, Repos: repos}
+	if err := writeJSONFile(path, cached); err != nil {
+		return repos, fmt.Errorf("failed to save remote repo cache: %w", err)
+	}
+	return repos, nil
+}
+
+func fetchOrgRepos(gh *ghSession, org string) ([]remoteRepo, error) {
+	const perPage = 100
+
+	var repos []remoteRepo
+	for page := 1; ; page++ {
+		output, err := gh.api("-X", "GET", "orgs/"+org+"/repos",
+			"-f", fmt.Sprintf("per_page=%d", perPage),
+			"-f", fmt.Sprintf("page=%d", page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s repositories: %w", org, err)
+		}
+
+		var results []struct {
+			FullName string `json:"full_name"`
+			Archived bool   `json:"archived"`
+		}
+		if err := json.Unmarshal(output, &results); err != nil {
+			return nil, fmt.Errorf("failed to parse %s repositories: %w", org, err)
+		}
+
+		for _, result := range results {
+			repos = append(repos, remoteRepo{
+				NameWithOwner: result.FullName,
+				Archived:      result.Archived,
+			})
+		}
+		if len(results) < perPage {
+			return repos, nil
+		}
+	}
+}
+
+// cloneRoot is what <root> stands for in the clone layout: QGH_WORKSPACE
+// when it's set, even if qgh runs inside a repo, or else the directory
+// searched.
+func cloneRoot(searchDir string) string {
+	if workspace := os.Getenv("QGH_WORKSPACE"); workspace != "" {
+		if stat, err := os.Stat(workspace); err == nil && stat.IsDir() {
+			return workspace
+		}
+	}
+	return searchDir
+}
+
+// clonePath expands the configured clone layout for a repository.
+func clonePath(layout, root, nameWithOwner string) string {
+	owner, name, _ := strings.Cut(nameWithOwner, "/")
+	path := strings.NewReplacer(
+		"<root>", root,
+		"<owner>", owner,
+		"<name>", name,
+	).Replace(layout)
+	return filepath.Clean(path)
+}
+
+func cloneRepoCmd(repo GitRepo, thenCd bool) tea.Cmd {
+	return func() tea.Msg {
+		nameWithOwner, _ := repoNameWithOwner(repo.GitHubURL)
+
+		if err := os.MkdirAll(filepath.Dir(repo.Directory), 0755); err != nil {
+			return repoClonedMsg{repo: repo, err: err}
+		}
+		// gh picks the user's preferred protocol and credentials
+		output, err := exec.Command("gh", "repo", "clone", nameWithOwner, repo.Directory).CombinedOutput()
+		if err != nil {
+			return repoClonedMsg{repo: repo, err: fmt.Errorf("%s", ghErrorText(err, string(output)))}
+		}
+
+		origin, err := getOriginRemote(repo.Directory)
+		if err != nil {
+			origin = "N/A"
+			if !errors.Is(err, errNoOrigin) {
+				repo.OriginErr = err
+			}
+		}
+		repo.Origin = origin
+		repo.RemoteOnly = false
+		return repoClonedMsg{repo: repo, thenCd: thenCd}
+	}
+}
+
+// enterRemoteMode switches the list to searching the configured orgs,
+// listing their repositories the first time.
+func (m model) enterRemoteMode() (model, tea.Cmd) {
+	m.remoteMode = true
+	m.prMode = false
+	m.issueMode = false
+	m.searchInput.setValue("")
+
+	if m.remoteRepos == nil && !m.remoteLoading && len(m.config.Orgs) > 0 && m.gh != nil {
+		m.remoteLoading = true
+		m.remoteErr = nil
+		m.startJob(jobRemoteRepos)
+		m.filterRepos()
+		return m, loadRemoteReposCmd(m.gh, m.config.Orgs)
+	}
+	m.filterRepos()
+	return m, nil
+}
+
+// filterRemoteRepos lists org repositories matching the search. Repos that
+// are already checked out are shown as their local copy.
+func (m *model) filterRemoteRepos() {
+	var matches []scoredRepo
+	for _, remote := range m.remoteRepos {
+		score, _, ok := m.query.matchPath(remote.NameWithOwner)
+		if !ok {
+			continue
+		}
+
+		repoURL := githubRepoURL(remote.NameWithOwner)
+		if local := m.localRepoFor(repoURL); local != nil {
+			if m.query.matchesFields(*local, m.cachedPRs(repoURL), m.localStatuses) {
+				matches = append(matches, scoredRepo{repo: *local, score: score})
+			}
+			continue
+		}
+		repo := GitRepo{
+			Directory:  clonePath(m.config.CloneLayout, m.workspaceRoot, remote.NameWithOwner),
+			Origin:     "N/A",
+			GitHubURL:  repoURL,
+			RemoteOnly: true,
+		}
+		if remote.Archived {
+			// Enough metadata for the list to dim it
+			repo.Meta = &RepoMetadata{Archived: true}
+		}
+		if m.query.matchesFields(repo, m.cachedPRs(repoURL), m.localStatuses) {
+			matches = append(matches, scoredRepo{repo: repo, score: score})
+		}
+	}
+	m.filteredRepos = rankRepos(matches, m.history)
+}
+
+// enclosingWorkTree returns the git working tree that path would end up
+// inside, or "" if none. Path itself needn't exist yet.
+func enclosingWorkTree(path string) string {
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// cloneSelected starts cloning the selected remote-only repo, unless a
+// clone of it is already running. Clones never go inside another working
+// tree, which is where they'd land when qgh runs inside a repo without
+// QGH_WORKSPACE.
+func (m model) cloneSelected(thenCd bool) (model, tea.Cmd) {
+	repo := m.filteredRepos[m.cursor]
+	if m.cloning[repo.GitHubURL] {
+		return m, nil
+	}
+	nameWithOwner, _ := repoNameWithOwner(repo.GitHubURL)
+	if workTree := enclosingWorkTree(repo.Directory); workTree != "" {
+		err := fmt.Errorf("%s is inside the git repo %s; set QGH_WORKSPACE or an absolute cloneLayout", repo.Directory, workTree)
+		m.cloneErrors[repo.GitHubURL] = err.Error()
+		m.reportError(cloneJob(nameWithOwner), err)
+		return m, nil
+	}
+	m.cloning[repo.GitHubURL] = true
+	delete(m.cloneErrors, repo.GitHubURL)
+	m.startJob(cloneJob(nameWithOwner))
+	return m, cloneRepoCmd(repo, thenCd)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	repoMetadataCacheFile = "repo-metadata.json"
+	repoMetadataTTL       = 24 * time.Hour
+	repoMetadataBatchSize = 50 // Repositories per GraphQL query
+)
+
+// RepoMetadata is what GitHub knows about a repository beyond its URL.
+type RepoMetadata struct {
+	DefaultBranch string    `json:"defaultBranch"`
+	Archived      bool      `json:"archived"`
+	Visibility    string    `json:"visibility"` // public, private or internal
+	Topics        []string  `json:"topics"`
+	Description   string    `json:"description"`
+	Language      string    `json:"language"`
+	FetchedAt     time.Time `json:"fetchedAt"`
+}
+
+type repoMetadataLoadedMsg struct {
+	metadata map[string]RepoMetadata // Keyed by GitHub repo URL
+	err      error
+}
+
+func loadRepoMetadataCmd(gh *ghSession, repos []GitRepo) tea.Cmd {
+	return func() tea.Msg {
+		metadata, err := loadRepoMetadata(gh, repos)
+		return repoMetadataLoadedMsg{metadata: metadata, err: err}
+	}
+}
+
+// loadRepoMetadata serves metadata from the on-disk cache and refetches
+// entries that are missing or older than repoMetadataTTL.
+func loadRepoMetadata(gh *ghSession, repos []GitRepo) (map[string]RepoMetadata, error) {
+	path, err := cachePath(repoMetadataCacheFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate metadata cache: %w", err)
+	}
+
+	cached := make(map[string]RepoMetadata)
+	if err := readJSONFile(path, &cached); err != nil {
+		// A corrupt cache is simply rebuilt
+		cached = make(map[string]RepoMetadata)
+	}
+
+	var stale []string
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		if _, ok := repoNameWithOwner(repo.GitHubURL); !ok || seen[repo.GitHubURL] {
+			continue
+		}
+		seen[repo.GitHubURL] = true
+		if md, ok := cached[repo.GitHubURL]; !ok || time.Since(md.FetchedAt) > repoMetadataTTL {
+			stale = append(stale, repo.GitHubURL)
+		}
+	}
+
+	if len(stale) == 0 {
+		return cached, nil
+	}
+	if err := gh.authError(); err != nil {
+		return cached, err
+	}
+
+	var fetchErr error
+	for start := 0; start < len(stale); start += repoMetadataBatchSize {
+		end := min(start+repoMetadataBatchSize, len(stale))
+		fetched, err := fetchRepoMetadata(gh, stale[start:end])
+		if err != nil {
+			fetchErr = err
+		}
+		for url, md := range fetched {
+			cached[url] = md
+		}
+	}
+
+	if err := writeJSONFile(path, cached); err != nil && fetchErr == nil {
+		fetchErr = fmt.Errorf("failed to save metadata cache: %w", err)
+	}
+	return cached, fetchErr
+}
+
+// fetchRepoMetadata looks up a batch of repositories in one GraphQL query,
+// one aliased repository field per URL. Repositories that no longer exist
+// are left out rather than failing the batch.
+func fetchRepoMetadata(gh *ghSession, repoURLs []string) (map[string]RepoMetadata, error) {
+	// Owners and names go in as variables, never spliced into the query text
+	var params, fields strings.Builder
+	args := []string{"graphql"}
+	for i, url := range repoURLs {
+		nameWithOwner, _ := repoNameWithOwner(url)
+		owner, name, _ := strings.Cut(nameWithOwner, "/")
+		fmt.Fprintf(&params, "$o%d: String!, $n%d: String!, ", i, i)
+		fmt.Fprintf(&fields, ` r%d: repository(owner: $o%d, name: $n%d) {
+			defaultBranchRef { name }
+			isArchived
+			visibility
+			description
+			primaryLanguage { name }
+			repositoryTopics(first: 20) { nodes { topic { name } } }
+		}`, i, i, i)
+		args = append(args, "-f", fmt.Sprintf("o%d=%s", i, owner), "-f", fmt.Sprintf("n%d=%s", i, name))
+	}
+	query := fmt.Sprintf("query(%s) {%s}", strings.TrimSuffix(params.String(), ", "), fields.String())
+
+	output, apiErr := gh.api(append(args, "-f", "query="+query)...)
+	if len(output) == 0 {
+		return nil, fmt.Errorf("failed to fetch repository metadata: %w", apiErr)
+	}
+
+	var response struct {
+		Data map[string]*struct {
+			DefaultBranchRef *struct {
+				Name string `json:"name"`
+			} `json:"defaultBranchRef"`
+			IsArchived      bool   `json:"isArchived"`
+			Visibility      string `json:"visibility"`
+			Description     string `json:"description"`
+			PrimaryLanguage *struct {
+				Name string `json:"name"`
+			} `json:"primaryLanguage"`
+			RepositoryTopics struct {
+				Nodes []struct {
+					Topic struct {
+						Name string `json:"name"`
+					} `json:"topic"`
+				} `json:"nodes"`
+			} `json:"repositoryTopics"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse repository metadata: %w", err)
+	}
+
+	metadata := make(map[string]RepoMetadata)
+	now := time.Now()
+	for i, url := range repoURLs {
+		repo := response.Data[fmt.Sprintf("r%d", i)]
+		if repo == nil {
+			continue
+		}
+		md := RepoMetadata{
+			Archived:    repo.IsArchived,
+			Visibility:  strings.ToLower(repo.Visibility),
+			Description: repo.Description,
+			FetchedAt:   now,
+		}
+		if repo.DefaultBranchRef != nil {
+			md.DefaultBranch = repo.DefaultBranchRef.Name
+		}
+		if repo.PrimaryLanguage != nil {
+			md.Language = repo.PrimaryLanguage.Name
+		}
+		for _, node := range repo.RepositoryTopics.Nodes {
+			md.Topics = append(md.Topics, node.Topic.Name)
+		}
+		metadata[url] = md
+	}
+	return metadata, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// useSavedSearch switches the list to a saved search's mode and query. The
+// caller refilters.
+func (m *model) useSavedSearch(search SavedSearch) {
+	m.prMode = search.Mode == "pr"
+	m.issueMode = false
+	m.remoteMode = false
+	m.regexMode = search.Regex
+	m.searchInput.setValue(search.Query)
+	m.recall = nil
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// openSavedSearches shows the picker of saved searches from the config.
+func (m model) openSavedSearches() (model, tea.Cmd) {
+	m.currentView = savedSearchesView
+	m.savedSearchCursor = 0
+	m.savedSearchScrollOffset = 0
+	return m, nil
+}
+
+// savedSearchesHelp is the key help under the saved searches picker.
+const savedSearchesHelp = "Use ↑/↓ to navigate, Enter to run the search, Esc to go back, Ctrl+C to quit"
+
+func (m model) savedSearchesVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + scroll indicators(2) + newline before footer(1) + status bar(1) = 7 lines, and the footer
+	visibleHeight := m.terminalHeight - 7 - m.footerHeight(savedSearchesHelp)
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+	return visibleHeight
+}
+
+func (m model) updateSavedSearchesView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	searches := m.config.Searches
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "ctrl+s":
+		m.currentView = listView
+	case "enter":
+		if m.savedSearchCursor < len(searches) {
+			m.useSavedSearch(searches[m.savedSearchCursor])
+			m.currentView = listView
+			return m.handleSearchChange()
+		}
+	case "up":
+		if m.savedSearchCursor > 0 {
+			m.savedSearchCursor--
+			if m.savedSearchCursor < m.savedSearchScrollOffset {
+				m.savedSearchScrollOffset = m.savedSearchCursor
+			}
+		}
+	case "down":
+		if m.savedSearchCursor < len(searches)-1 {
+			m.savedSearchCursor++
+			visibleHeight := m.savedSearchesVisibleHeight()
+			if m.savedSearchCursor >= m.savedSearchScrollOffset+visibleHeight {
+				m.savedSearchScrollOffset = m.savedSearchCursor - visibleHeight + 1
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderSavedSearchesView() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("62")).
+		Foreground(lipgloss.Color("230"))
+
+	nameStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("14"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	searches := m.config.Searches
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Saved Searches (%d)", len(searches))))
+	b.WriteString("\n\n")
+
+	if len(searches) == 0 {
+		path, _ := configPath()
+		b.WriteString(fmt.Sprintf("No saved searches. Add \"searches\" to %s\n", path))
+	} else {
+		if m.savedSearchScrollOffset > 0 {
+			b.WriteString("↑ (more above)\n")
+		} else {
+			b.WriteString("\n")
+		}
+
+		// Names can be wide or combining characters; pad to display width
+		nameWidth := 0
+		for _, search := range searches {
+			nameWidth = max(nameWidth, uniseg.StringWidth("@"+search.Name))
+		}
+
+		visibleHeight := m.savedSearchesVisibleHeight()
+		endIdx := min(m.savedSearchScrollOffset+visibleHeight, len(searches))
+		for i := m.savedSearchScrollOffset; i < endIdx; i++ {
+			search := searches[i]
+			name := "@" + search.Name
+			name += strings.Repeat(" ", nameWidth-uniseg.StringWidth(name))
+			var tags []string
+			if search.Mode == "pr" {
+				tags = append(tags, "PR mode")
+			}
+			if search.Regex {
+				tags = append(tags, "regex")
+			}
+			note := ""
+			if len(tags) > 0 {
+				note = "  (" + strings.Join(tags, ", ") + ")"
+			}
+
+			if i == m.savedSearchCursor {
+				b.WriteString(selectedStyle.Render(fmt.Sprintf("%s  %s%s", name, search.Query, note)))
+			} else {
+				b.WriteString(nameStyle.Render(name) + "  " + search.Query + dimStyle.Render(note))
+			}
+			b.WriteString("\n")
+		}
+
+		if endIdx < len(searches) {
+			b.WriteString("↓ (more below)\n")
+		} else {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(savedSearchesHelp))
+
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const searchesFile = "searches.json"
+
+// searchHistoryCap is how many searches are kept per mode.
+const searchHistoryCap = 100
+
+// searchHistory holds the searches that led to a selection, most recent
+// first, kept apart for normal and PR mode since they search different
+// things.
+type searchHistory struct {
+	Normal []pastSearch `json:"normal"`
+	PR     []pastSearch `json:"pr"`
+}
+
+// pastSearch is a search in the history, with whether it was a pattern.
+type pastSearch struct {
+	Query string `json:"query"`
+	Regex bool   `json:"regex,omitempty"` // Typed in regex mode
+}
+
+// UnmarshalJSON also reads the plain strings that histories were saved as
+// before regex mode was remembered.
+func (s *pastSearch) UnmarshalJSON(data []byte) error {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		*s = pastSearch{Query: query}
+		return nil
+	}
+	type entry pastSearch // Without this method
+	return json.Unmarshal(data, (*entry)(s))
+}
+
+// searchRecall tracks cycling through past searches with Ctrl+R or ↑.
+type searchRecall struct {
+	index  int        // Position in the history shown, -1 for the draft
+	draft  pastSearch // What was typed before recalling
+	shown  pastSearch // The search recall put in the box
+	prMode bool       // Which history is being cycled
+}
+
+func loadSearchHistory() (*searchHistory, error) {
+	history := &searchHistory{}
+	path, err := dataPath(searchesFile)
+	if err != nil {
+		return history, err
+	}
+	if err := readJSONFile(path, history); err != nil {
+		return &searchHistory{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return history, nil
+}
+
+func saveSearchHistory(history *searchHistory) error {
+	path, err := dataPath(searchesFile)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, history)
+}
+
+func (h *searchHistory) searches(prMode bool) *[]pastSearch {
+	if prMode {
+		return &h.PR
+	}
+	return &h.Normal
+}
+
+// add moves search to the front of its mode's history, dropping earlier
+// copies and the oldest searches past the cap. Searches that differ only in
+// spacing count as the same.
+func (h *searchHistory) add(prMode bool, search pastSearch) {
+	search.Query = strings.Join(strings.Fields(search.Query), " ")
+	if search.Query == "" {
+		return
+	}
+	searches := h.searches(prMode)
+	kept := []pastSearch{search}
+	for _, s := range *searches {
+		if s != search && len(kept) < searchHistoryCap {
+			kept = append(kept, s)
+		}
+	}
+	*searches = kept
+}
+
+// searchHistoryMode reports which history the list's current mode keeps.
+// Issues and remote mode don't keep one.
+func (m model) searchHistoryMode() (prMode, ok bool) {
+	if m.issueMode || m.remoteMode {
+		return false, false
+	}
+	return m.prMode, true
+}
+
+// recallSearch replaces the search with the next older one from the
+// history, wrapping back to what was typed after the oldest.
+func (m model) recallSearch() (tea.Model, tea.Cmd) {
+	prMode, ok := m.searchHistoryMode()
+	if !ok || m.searchHistory == nil {
+		return m, nil
+	}
+	searches := *m.searchHistory.searches(prMode)
+	if len(searches) == 0 {
+		return m, nil
+	}
+
+	// Any edit since the last recall starts over from the newest search
+	current := pastSearch{Query: m.searchInput.value(), Regex: m.regexMode}
+	if m.recall == nil || m.recall.shown != current || m.recall.prMode != prMode {
+		m.recall = &searchRecall{index: -1, draft: current, prMode: prMode}
+	}
+	recall := *m.recall
+	recall.index++
+	if recall.index >= len(searches) {
+		recall.index = -1
+		recall.shown = recall.draft
+	} else {
+		recall.shown = searches[recall.index]
+	}
+	m.recall = &recall
+
+	m.searchInput.setValue(recall.shown.Query)
+	m.regexMode = recall.shown.Regex
+	m.cursor = 0
+	return m.handleSearchChange()
+}
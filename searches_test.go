@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSearchHistoryJSON(t *testing.T) {
+	// Histories saved before regex mode was remembered hold plain strings
+	data := `{"normal": ["istio", {"query": "^ops-", "regex": true}], "pr": [{"query": "fix"}]}`
+	var history searchHistory
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		t.Fatal(err)
+	}
+	want := searchHistory{
+		Normal: []pastSearch{{Query: "istio"}, {Query: "^ops-", Regex: true}},
+		PR:     []pastSearch{{Query: "fix"}},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("history = %+v, want %+v", history, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"normal": [1]}`), &history); err == nil {
+		t.Error("a number was read as a search")
+	}
+}
+
+func TestSearchHistoryAdd(t *testing.T) {
+	var history searchHistory
+	history.add(false, pastSearch{Query: "ops"})
+	history.add(false, pastSearch{Query: "ops", Regex: true})
+	history.add(false, pastSearch{Query: "  web   api "})
+	history.add(false, pastSearch{Query: "ops"})
+	history.add(false, pastSearch{Query: " "})
+	history.add(true, pastSearch{Query: "fix"})
+
+	// The same text as a pattern is a different search
+	want := []pastSearch{{Query: "ops"}, {Query: "web api"}, {Query: "ops", Regex: true}}
+	if !reflect.DeepEqual(history.Normal, want) {
+		t.Errorf("normal history = %+v, want %+v", history.Normal, want)
+	}
+	if !reflect.DeepEqual(history.PR, []pastSearch{{Query: "fix"}}) {
+		t.Errorf("PR history = %+v, want just fix", history.PR)
+	}
+}
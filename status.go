@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// noticeDuration is how long a confirmation stays in the status bar.
+const noticeDuration = 3 * time.Second
+
+// Background jobs shown in the status bar while they run. Jobs that can
+// fail are also what the last error is attributed to.
+const (
+	jobConnect       = "connecting to GitHub"
+	jobPRCache       = "refreshing PRs"
+	jobRepoPRs       = "loading PRs"
+	jobIssues        = "loading issues"
+	jobMetadata      = "fetching repo metadata"
+	jobRemoteRepos   = "listing org repos"
+	jobReleaseScan   = "scanning releases"
+	jobReleaseInfo   = "loading releases"
+	jobWorkflowRuns  = "loading workflow runs"
+	jobRerun         = "rerunning failed jobs"
+	jobNotifications = "loading notifications"
+	jobMarkRead      = "marking notification read"
+	jobLocalStatus   = "reading git status"
+	jobSync          = "syncing repos"
+	jobExec          = "running command"
+)
+
+// cloneJob is the job label for cloning one repository.
+func cloneJob(nameWithOwner string) string {
+	return "cloning " + nameWithOwner
+}
+
+// statusError is the last failure shown in the status bar, with what qgh
+// was doing when it happened.
+type statusError struct {
+	job string
+	err error
+}
+
+type clearNoticeMsg struct {
+	seq int
+}
+
+type pathCopiedMsg struct {
+	err error
+}
+
+// startJob marks a background job as running. The same job can run more
+// than once at a time, e.g. several clones.
+func (m *model) startJob(job string) {
+	if m.jobs == nil {
+		m.jobs = make(map[string]int)
+	}
+	m.jobs[job]++
+}
+
+// finishJob marks a background job as done, recording its error. A job that
+// succeeds clears the error it reported last time.
+func (m *model) finishJob(job string, err error) {
+	if m.jobs[job] > 1 {
+		m.jobs[job]--
+	} else {
+		delete(m.jobs, job)
+	}
+	if err != nil {
+		m.reportError(job, err)
+	} else if m.lastErr != nil && m.lastErr.job == job {
+		m.lastErr = nil
+	}
+}
+
+// reportError shows a failure in the status bar until it's replaced.
+func (m *model) reportError(job string, err error) {
+	m.lastErr = &statusError{job: job, err: err}
+}
+
+// notify shows a short-lived confirmation in the status bar.
+func (m *model) notify(text string) tea.Cmd {
+	m.noticeSeq++
+	m.notice = text
+	seq := m.noticeSeq
+	return tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return clearNoticeMsg{seq: seq}
+	})
+}
+
+// openInBrowser opens a URL and reports the outcome in the status bar.
+func (m *model) openInBrowser(url string) tea.Cmd {
+	if err := openURL(url); err != nil {
+		m.reportError("opening browser", err)
+		return nil
+	}
+	return m.notify("Opened in browser")
+}
+
+func copyPathCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		return pathCopiedMsg{err: copyToClipboard(path)}
+	}
+}
+
+// copyToClipboard hands text to the platform's clipboard tool.
+func copyToClipboard(text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %s", candidate[0], ghErrorText(err, string(output)))
+		}
+		return nil
+	}
+	return errors.New("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+}
+
+// renderStatusBar shows confirmations, running jobs and the last error on a
+// single line above the footer.
+func (m model) renderStatusBar() string {
+	noticeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("2"))
+
+	jobStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9"))
+
+	if m.confirm != nil {
+		// The question blocks other keys, so it's all the bar shows
+		confirmStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("11"))
+		line := confirmStyle.Render("? " + m.confirm.prompt + " (y/n)")
+		if m.terminalWidth > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(line)
+		}
+		return line
+	}
+
+	var parts []string
+	if m.notice != "" {
+		parts = append(parts, noticeStyle.Render("✓ "+m.notice))
+	}
+	if len(m.jobs) > 0 {
+		var jobs []string
+		for job, count := range m.jobs {
+			if count > 1 {
+				job = fmt.Sprintf("%s (%d)", job, count)
+			}
+			jobs = append(jobs, job)
+		}
+		sort.Strings(jobs)
+		parts = append(parts, jobStyle.Render("⟳ "+strings.Join(jobs, ", ")))
+	}
+	if m.lastErr != nil {
+		// gh and git errors can span lines; keep the bar to one
+		cause := strings.Join(strings.Fields(m.lastErr.err.Error()), " ")
+		parts = append(parts, errorStyle.Render(fmt.Sprintf("✗ %s failed: %s", m.lastErr.job, cause)))
+	}
+
+	line := strings.Join(parts, "  ")
+	if m.terminalWidth > 0 {
+		line = lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(line)
+	}
+	return line
+}
+
+// renderFooter wraps a view's key help to the terminal width. Views size
+// their lists with footerHeight, so a long help line can't push the header
+// off screen.
+func (m model) renderFooter(help string) string {
+	if m.terminalWidth <= 0 {
+		return help
+	}
+	return lipgloss.NewStyle().Width(m.terminalWidth).Render(help)
+}
+
+// footerHeight is how many lines renderFooter takes for the help.
+func (m model) footerHeight(help string) int {
+	return lipgloss.Height(m.renderFooter(help))
+}
+
+// reportStartupErrors surfaces problems found before the TUI started: a bad
+// config or history file, and repositories whose origin couldn't be read and so show
+// up without a GitHub URL.
+func (m *model) reportStartupErrors(configErr, historyErr error) {
+	var failed []GitRepo
+	for _, repo := range m.repos {
+		if repo.OriginErr != nil {
+			failed = append(failed, repo)
+		}
+	}
+	if len(failed) == 1 {
+		m.reportError("reading origin remote", fmt.Errorf("%s: %w", failed[0].Directory, failed[0].OriginErr))
+	} else if len(failed) > 1 {
+		m.reportError("reading origin remotes", fmt.Errorf("%d repos, first %s: %w", len(failed), failed[0].Directory, failed[0].OriginErr))
+	}
+
+	if historyErr != nil {
+		m.reportError("reading history", historyErr)
+	}
+	if configErr != nil {
+		m.reportError("reading config", configErr)
+	}
+}
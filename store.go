@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// cachePath returns the path of a file in qgh's cache directory, creating
+// the directory if needed. QGH_CACHE_DIR overrides the platform default.
+func cachePath(name string) (string, error) {
+	dir := os.Getenv("QGH_CACHE_DIR")
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(userCacheDir, "qgh")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// dataPath returns the path of a file in qgh's data directory, for state
+// that unlike the cache can't be fetched again, creating the directory if
+// needed. QGH_DATA_DIR overrides the platform default, which follows
+// XDG_DATA_HOME on Linux and the BSDs.
+func dataPath(name string) (string, error) {
+	dir := os.Getenv("QGH_DATA_DIR")
+	if dir == "" {
+		base := os.Getenv("XDG_DATA_HOME")
+		if base == "" {
+			var err error
+			switch runtime.GOOS {
+			case "darwin", "windows":
+				base, err = os.UserConfigDir()
+			default:
+				base, err = os.UserHomeDir()
+				base = filepath.Join(base, ".local", "share")
+			}
+			if err != nil {
+				return "", err
+			}
+		}
+		dir = filepath.Join(base, "qgh")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// readJSONFile decodes a JSON file into v. A missing file is not an error
+// and leaves v untouched.
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSONFile atomically replaces path with v encoded as JSON, so a crash
+// mid-write never leaves a truncated cache behind.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
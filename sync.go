@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// syncOutcome is how syncing one repo ended.
+type syncOutcome int
+
+const (
+	syncUpToDate syncOutcome = iota
+	syncPulled               // Fast-forwarded to the upstream
+	syncFetched              // Fetched, but there's no upstream to pull from
+	syncDirty                // Fetched, but not pulled over uncommitted changes
+	syncDiverged             // Fetched, but local commits rule out a fast-forward
+	syncFailed
+)
+
+type syncResult struct {
+	outcome          syncOutcome
+	ahead            int  // Local commits not on the upstream
+	behind           int  // Upstream commits pulled, or not pulled if skipped
+	untracked        bool // Dirty because untracked files are in the way
+	needsCredentials bool // Failed because the remote asked for a password or key
+	err              error
+}
+
+// syncEventMsg reports progress on one repo of a sync: a new phase while
+// it runs, then its result.
+type syncEventMsg struct {
+	index  int
+	phase  string
+	result *syncResult
+}
+
+type syncFinishedMsg struct{}
+
+// syncRun is a sync across repos, in the TUI.
+type syncRun struct {
+	repos        []GitRepo
+	phases       []string // "queued", "fetching" or "pulling" until there's a result
+	results      []*syncResult
+	events       chan syncEventMsg
+	finished     int
+	done         bool
+	cursor       int
+	scrollOffset int
+}
+
+// syncRepos fetches and fast-forwards repos, parallelism at a time, sending
+// their progress on events and closing it when they're all done.
+func syncRepos(repos []GitRepo, parallelism int, events chan<- syncEventMsg) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, dir string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := syncRepo(dir, func(phase string) {
+				events <- syncEventMsg{index: i, phase: phase}
+			})
+			events <- syncEventMsg{index: i, result: &result}
+		}(i, repo.Directory)
+	}
+	wg.Wait()
+	close(events)
+}
+
+// syncRepo fetches a repo and fast-forwards its branch to the upstream,
+// like git pull --ff-only, unless that would touch uncommitted changes or
+// can't be done because the branch has diverged. Untracked files only get
+// in the way when the upstream adds the same paths, which git refuses.
+func syncRepo(dir string, report func(phase string)) syncResult {
+	status, err := getLocalStatus(dir)
+	if err != nil {
+		return syncResult{outcome: syncFailed, err: err}
+	}
+
+	report("fetching")
+	if _, err := syncGit(dir, "fetch", "--prune", "--quiet"); err != nil {
+		return syncResult{outcome: syncFailed, needsCredentials: isCredentialFailure(err.Error()), err: err}
+	}
+	if status.Branch == "" || !status.Upstream {
+		return syncResult{outcome: syncFetched}
+	}
+
+	// Compare with the upstream as just fetched
+	counts, err := syncGit(dir, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return syncResult{outcome: syncFailed, err: err}
+	}
+	var result syncResult
+	if _, err := fmt.Sscanf(counts, "%d %d", &result.ahead, &result.behind); err != nil {
+		return syncResult{outcome: syncFailed, err: fmt.Errorf("unexpected rev-list output %q", counts)}
+	}
+
+	switch {
+	case result.behind == 0:
+		result.outcome = syncUpToDate
+	case result.ahead > 0:
+		result.outcome = syncDiverged
+	case status.Modified:
+		result.outcome = syncDirty
+	default:
+		report("pulling")
+		if _, err := syncGit(dir, "merge", "--ff-only", "--quiet", "@{upstream}"); err != nil {
+			if strings.Contains(err.Error(), "untracked working tree files would be overwritten") {
+				result.outcome = syncDirty
+				result.untracked = true
+				return result
+			}
+			return syncResult{outcome: syncFailed, err: err}
+		}
+		result.outcome = syncPulled
+	}
+	return result
+}
+
+func syncGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	// Untranslated, since some errors are recognised by their text, and
+	// failing rather than prompting for credentials nobody would see
+	cmd.Env = append(nonInteractiveGitEnv(), "LC_ALL=C")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0], ghErrorText(err, stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// credentialFailures are what git and ssh print, untranslated, when a
+// remote wanted a password, key or host key confirmation they couldn't ask for.
+var credentialFailures = []string{
+	"terminal prompts disabled",
+	"could not read Username",
+	"could not read Password",
+	"Authentication failed",
+	"Permission denied (publickey",
+	"Host key verification failed",
+}
+
+// isCredentialFailure reports whether a git error is a remote asking for
+// credentials.
+func isCredentialFailure(text string) bool {
+	for _, failure := range credentialFailures {
+		if strings.Contains(text, failure) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe says how a sync ended, in a few words.
+func (r syncResult) describe() string {
+	switch r.outcome {
+	case syncPulled:
+		return fmt.Sprintf("pulled %d %s", r.behind, plural(r.behind, "commit"))
+	case syncFetched:
+		return "fetched, no upstream to pull"
+	case syncDirty:
+		if r.untracked {
+			return fmt.Sprintf("skipped, untracked files in the way (↓%d)", r.behind)
+		}
+		return fmt.Sprintf("skipped, uncommitted changes (↓%d)", r.behind)
+	case syncDiverged:
+		return fmt.Sprintf("skipped, diverged (↑%d ↓%d)", r.ahead, r.behind)
+	case syncFailed:
+		if r.needsCredentials {
+			return "needs credentials, fetch it by hand once to sign in"
+		}
+		return strings.Join(strings.Fields(r.err.Error()), " ")
+	}
+	if r.ahead > 0 {
+		return fmt.Sprintf("up to date, ↑%d to push", r.ahead)
+	}
+	return "up to date"
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+// syncSummary counts the results by outcome, e.g. "3 pulled, 1 failed".
+func syncSummary(results []*syncResult) string {
+	counts := make(map[syncOutcome]int)
+	for _, result := range results {
+		if result != nil {
+			counts[result.outcome]++
+		}
+	}
+	var parts []string
+	for _, outcome := range []struct {
+		outcome syncOutcome
+		label   string
+	}{
+		{syncPulled, "pulled"},
+		{syncUpToDate, "up to date"},
+		{syncFetched, "fetched only"},
+		{syncDirty, "dirty"},
+		{syncDiverged, "diverged"},
+		{syncFailed, "failed"},
+	} {
+		if counts[outcome.outcome] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[outcome.outcome], outcome.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func waitForSyncEvent(events <-chan syncEventMsg) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return syncFinishedMsg{}
+		}
+		return event
+	}
+}
+
+// startSync asks to sync the marked repos or the ones in the list, or shows
+// the sync that's still running.
+func (m model) startSync() (model, tea.Cmd) {
+	if m.sync != nil && !m.sync.done {
+		m.currentView = syncView
+		return m, nil
+	}
+	repos, marked := m.bulkTargets()
+	if len(repos) == 0 {
+		return m, nil
+	}
+	m.confirm = &confirmation{
+		prompt: fmt.Sprintf("Fetch and fast-forward %s?", describeTargets(repos, marked)),
+		run: func(m model) (model, tea.Cmd) {
+			return m.runSync(repos)
+		},
+	}
+	return m, nil
+}
+
+// runSync starts syncing repos in the background and shows its progress.
+func (m model) runSync(repos []GitRepo) (model, tea.Cmd) {
+	run := &syncRun{
+		repos:   repos,
+		phases:  make([]string, len(repos)),
+		results: make([]*syncResult, len(repos)),
+		events:  make(chan syncEventMsg),
+	}
+	for i := range run.phases {
+		run.phases[i] = "queued"
+	}
+	m.sync = run
+	m.currentView = syncView
+	m.startJob(jobSync)
+	go syncRepos(repos, m.config.parallelism(), run.events)
+	return m, waitForSyncEvent(run.events)
+}
+
+func (run *syncRun) apply(event syncEventMsg) {
+	if event.result != nil {
+		run.results[event.index] = event.result
+		run.finished++
+	} else {
+		run.phases[event.index] = event.phase
+	}
+}
+
+// syncFooter is the key help under the sync view.
+func (m model) syncFooter() string {
+	if m.sync.done {
+		return "Use ↑/↓ to navigate, Esc to go back, Alt+S in the list to sync again, Ctrl+C to quit"
+	}
+	return "Use ↑/↓ to navigate, Esc to go back while the sync carries on, Ctrl+C to quit"
+}
+
+func (m model) syncVisibleHeight() int {
+	// Header(1) + 2 newlines(2) + scroll indicators(2) + newline before footer(1) + status bar(1) = 7 lines, and the footer
+	visibleHeight := m.terminalHeight - 7 - m.footerHeight(m.syncFooter())
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+	return visibleHeight
+}
+
+func (m model) updateSyncView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	run := m.sync
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		// A running sync carries on in the background
+		m.currentView = listView
+	case "up":
+		if run.cursor > 0 {
+			run.cursor--
+			if run.cursor < run.scrollOffset {
+				run.scrollOffset = run.cursor
+			}
+		}
+	case "down":
+		if run.cursor < len(run.repos)-1 {
+			run.cursor++
+			visibleHeight := m.syncVisibleHeight()
+			if run.cursor >= run.scrollOffset+visibleHeight {
+				run.scrollOffset = run.cursor - visibleHeight + 1
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderSyncView() string {
+	var b strings.Builder
+	run := m.sync
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("62")).
+		Foreground(lipgloss.Color("230"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	runningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	outcomeStyles := map[syncOutcome]lipgloss.Style{
+		syncUpToDate: dimStyle,
+		syncPulled:   lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		syncFetched:  dimStyle,
+		syncDirty:    lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		syncDiverged: lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+		syncFailed:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+	}
+
+	header := fmt.Sprintf("Sync: %d/%d done", run.finished, len(run.repos))
+	if summary := syncSummary(run.results); summary != "" {
+		header += " - " + summary
+	}
+	b.WriteString(headerStyle.Render(header))
+	b.WriteString("\n\n")
+
+	if run.scrollOffset > 0 {
+		b.WriteString("↑ (more above)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	labels := calculateMinimalPaths(run.repos)
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, uniseg.StringWidth(label))
+	}
+
+	endIdx := min(run.scrollOffset+m.syncVisibleHeight(), len(run.repos))
+	for i := run.scrollOffset; i < endIdx; i++ {
+		label := labels[i] + strings.Repeat(" ", labelWidth-uniseg.StringWidth(labels[i]))
+		var state string
+		style := runningStyle
+		if result := run.results[i]; result != nil {
+			state, style = result.describe(), outcomeStyles[result.outcome]
+		} else if run.phases[i] == "queued" {
+			state, style = "queued", dimStyle
+		} else {
+			state = run.phases[i] + "..."
+		}
+
+		line := label + "  " + style.Render(state)
+		if i == run.cursor {
+			line = selectedStyle.Render(label + "  " + state)
+		}
+		if m.terminalWidth > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if endIdx < len(run.repos) {
+		b.WriteString("↓ (more below)\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(m.syncFooter()))
+
+	return b.String()
+}
+
+// runSyncCommand implements `qgh sync [-j N] [search]`, returning the exit
+// code: 1 if any repo failed to sync.
+func runSyncCommand(repos []GitRepo, config *Config, args []string) int {
+	flags := flag.NewFlagSet("sync", flag.ExitOnError)
+	parallelism := flags.Int("j", config.parallelism(), "Number of repos to sync at once")
+	flags.Parse(args)
+	if *parallelism < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -j must be at least 1")
+		return 2
+	}
+
+	repos, err := filterReposForCLI(repos, config, strings.Join(flags.Args(), " "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(repos) == 0 {
+		fmt.Println("No repositories to sync.")
+		return 0
+	}
+
+	labels := calculateMinimalPaths(repos)
+	results := make([]*syncResult, len(repos))
+	events := make(chan syncEventMsg)
+	go syncRepos(repos, *parallelism, events)
+
+	// Report each repo as it finishes
+	finished := 0
+	for event := range events {
+		if event.result == nil {
+			continue
+		}
+		finished++
+		results[event.index] = event.result
+		fmt.Printf("[%*d/%d] %s: %s\n", len(fmt.Sprint(len(repos))), finished, len(repos), labels[event.index], event.result.describe())
+	}
+
+	fmt.Printf("\nSynced %d %s: %s\n", len(repos), plural(len(repos), "repo"), syncSummary(results))
+	exitCode := 0
+	for _, group := range []struct {
+		outcome syncOutcome
+		title   string
+	}{
+		{syncFailed, "Failed"},
+		{syncDiverged, "Diverged, not pulled"},
+		{syncDirty, "Local changes in the way, not pulled"},
+	} {
+		var lines []string
+		for i, result := range results {
+			if result.outcome == group.outcome {
+				lines = append(lines, fmt.Sprintf("  %s: %s", labels[i], result.describe()))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if group.outcome == syncFailed {
+			exitCode = 1
+		}
+		fmt.Printf("\n%s:\n%s\n", group.title, strings.Join(lines, "\n"))
+	}
+	return exitCode
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsCredentialFailure(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"git fetch: fatal: could not read Username for 'https://github.com': terminal prompts disabled", true},
+		{"git fetch: git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", true},
+		{"git fetch: Host key verification failed.\nfatal: Could not read from remote repository.", true},
+		{"git fetch: remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/acme/web.git/'", true},
+		{"git fetch: fatal: unable to access 'https://github.com/acme/web.git/': Could not resolve host: github.com", false},
+		{"git fetch: fatal: 'origin' does not appear to be a git repository", false},
+	}
+	for _, tt := range tests {
+		if got := isCredentialFailure(tt.text); got != tt.want {
+			t.Errorf("isCredentialFailure(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSyncResultDescribe(t *testing.T) {
+	tests := []struct {
+		result syncResult
+		want   string
+	}{
+		{syncResult{outcome: syncPulled, behind: 1}, "pulled 1 commit"},
+		{syncResult{outcome: syncPulled, behind: 3}, "pulled 3 commits"},
+		{syncResult{outcome: syncUpToDate, ahead: 2}, "up to date, ↑2 to push"},
+		{syncResult{outcome: syncDirty, behind: 4, untracked: true}, "skipped, untracked files in the way (↓4)"},
+		{syncResult{outcome: syncDiverged, ahead: 1, behind: 2}, "skipped, diverged (↑1 ↓2)"},
+		{syncResult{outcome: syncFailed, err: errors.New("git fetch: fatal:\n  no remote")}, "git fetch: fatal: no remote"},
+		{syncResult{outcome: syncFailed, needsCredentials: true, err: errors.New("terminal prompts disabled")}, "needs credentials, fetch it by hand once to sign in"},
+	}
+	for _, tt := range tests {
+		if got := tt.result.describe(); got != tt.want {
+			t.Errorf("describe(%+v) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// textInput is a single-line editor with a cursor and readline-style keys.
+// It only handles keys that edit text or move the cursor, so whatever it
+// doesn't claim is free to be a command.
+type textInput struct {
+	runes  []rune
+	cursor int // Rune offset, always at the start of a grapheme cluster
+}
+
+func (t textInput) value() string {
+	return string(t.runes)
+}
+
+// setValue replaces the text and puts the cursor at its end.
+func (t *textInput) setValue(s string) {
+	t.runes = []rune(s)
+	t.cursor = len(t.runes)
+}
+
+// update applies an editing key, reporting whether the text changed as
+// opposed to just the cursor. Other keys are ignored.
+func (t *textInput) update(msg tea.KeyMsg) bool {
+	before := t.value()
+
+	switch msg.String() {
+	case "left", "ctrl+b":
+		t.cursor = t.prevBoundary(t.cursor)
+	case "right", "ctrl+f":
+		t.cursor = t.nextBoundary(t.cursor)
+	case "home", "ctrl+a":
+		t.cursor = 0
+	case "end", "ctrl+e":
+		t.cursor = len(t.runes)
+	case "alt+b", "alt+left", "ctrl+left":
+		t.cursor = t.wordStart(t.cursor)
+	case "alt+f", "alt+right", "ctrl+right":
+		t.cursor = t.wordEnd(t.cursor)
+	case "backspace", "ctrl+h":
+		t.deleteTo(t.prevBoundary(t.cursor))
+	case "delete":
+		t.deleteTo(t.nextBoundary(t.cursor))
+	case "ctrl+u":
+		t.deleteTo(0)
+	case "ctrl+k":
+		t.deleteTo(len(t.runes))
+	case "ctrl+w":
+		// Back to the previous space, like readline's unix-word-rubout, which
+		// removes one query term at a time
+		start := t.cursor
+		for start > 0 && unicode.IsSpace(t.runes[start-1]) {
+			start--
+		}
+		for start > 0 && !unicode.IsSpace(t.runes[start-1]) {
+			start--
+		}
+		t.deleteTo(t.clusterStart(start))
+	case "alt+backspace", "ctrl+backspace":
+		t.deleteTo(t.wordStart(t.cursor))
+	case "alt+d", "alt+delete":
+		t.deleteTo(t.wordEnd(t.cursor))
+	default:
+		// Typed or pasted text, in any script
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace || msg.Alt && !msg.Paste {
+			return false
+		}
+		t.insert(sanitizeInput(string(msg.Runes)))
+	}
+	// Typing a combining mark or deleting between clusters can merge them
+	// around the cursor; keep it after the merged cluster
+	t.cursor = t.clusterEnd(t.cursor)
+	return t.value() != before
+}
+
+// sanitizeInput flattens pasted text onto one line and drops control
+// characters.
+func sanitizeInput(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func (t *textInput) insert(s string) {
+	inserted := []rune(s)
+	runes := make([]rune, 0, len(t.runes)+len(inserted))
+	runes = append(runes, t.runes[:t.cursor]...)
+	runes = append(runes, inserted...)
+	runes = append(runes, t.runes[t.cursor:]...)
+	t.runes = runes
+	t.cursor += len(inserted)
+}
+
+// deleteTo removes the text between the cursor and pos, which can be on
+// either side of it.
+func (t *textInput) deleteTo(pos int) {
+	start, end := min(pos, t.cursor), max(pos, t.cursor)
+	t.runes = append(t.runes[:start:start], t.runes[end:]...)
+	t.cursor = start
+}
+
+// boundaries returns the rune offsets where grapheme clusters start, plus the
+// end of the text, so the cursor never splits an accent from its letter.
+func (t textInput) boundaries() []int {
+	offsets := []int{0}
+	offset := 0
+	graphemes := uniseg.NewGraphemes(string(t.runes))
+	for graphemes.Next() {
+		offset += len(graphemes.Runes())
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+func (t textInput) prevBoundary(pos int) int {
+	prev := 0
+	for _, offset := range t.boundaries() {
+		if offset >= pos {
+			break
+		}
+		prev = offset
+	}
+	return prev
+}
+
+// clusterStart is the start of the grapheme cluster that pos is in.
+func (t textInput) clusterStart(pos int) int {
+	start := 0
+	for _, offset := range t.boundaries() {
+		if offset > pos {
+			break
+		}
+		start = offset
+	}
+	return start
+}
+
+// clusterEnd is pos if a grapheme cluster starts there, else the end of the
+// one it is in.
+func (t textInput) clusterEnd(pos int) int {
+	for _, offset := range t.boundaries() {
+		if offset >= pos {
+			return offset
+		}
+	}
+	return len(t.runes)
+}
+
+func (t textInput) nextBoundary(pos int) int {
+	for _, offset := range t.boundaries() {
+		if offset > pos {
+			return offset
+		}
+	}
+	return len(t.runes)
+}
+
+// wordStart is the start of the word before pos, skipping separators first.
+// A word that starts with a mark on a separator takes the separator along.
+func (t textInput) wordStart(pos int) int {
+	for pos > 0 && !isWordRune(t.runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(t.runes[pos-1]) {
+		pos--
+	}
+	return t.clusterStart(pos)
+}
+
+// wordEnd is the end of the word after pos, skipping separators first.
+func (t textInput) wordEnd(pos int) int {
+	for pos < len(t.runes) && !isWordRune(t.runes[pos]) {
+		pos++
+	}
+	for pos < len(t.runes) && isWordRune(t.runes[pos]) {
+		pos++
+	}
+	return t.clusterEnd(pos)
+}
+
+// view renders the text with the grapheme under the cursor in reverse
+// video, or a reversed space when the cursor is at the end.
+func (t textInput) view() string {
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+	end := t.nextBoundary(t.cursor)
+	under := string(t.runes[t.cursor:end])
+	if under == "" {
+		under = " "
+	}
+	return string(t.runes[:t.cursor]) + cursorStyle.Render(under) + string(t.runes[end:])
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editingKeys are the keys textInput handles, besides typing.
+var editingKeys = []tea.KeyMsg{
+	{Type: tea.KeyLeft},
+	{Type: tea.KeyRight},
+	{Type: tea.KeyHome},
+	{Type: tea.KeyEnd},
+	{Type: tea.KeyLeft, Alt: true},
+	{Type: tea.KeyRight, Alt: true},
+	{Type: tea.KeyBackspace},
+	{Type: tea.KeyDelete},
+	{Type: tea.KeyCtrlW},
+	{Type: tea.KeyBackspace, Alt: true},
+	{Type: tea.KeyRunes, Runes: []rune("d"), Alt: true},
+}
+
+func TestTextInputBackspaceProperties(t *testing.T) {
+	property := func(text unicodeText, moves uint8) bool {
+		var input textInput
+		input.setValue(string(text))
+		for range int(moves) % 8 {
+			input.update(tea.KeyMsg{Type: tea.KeyLeft})
+		}
+		before, cursor := input.value(), input.cursor
+		input.update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+		// Exactly the cluster before the cursor goes, and nothing else
+		runes := []rune(before)
+		prev := textInput{runes: runes}.prevBoundary(cursor)
+		want := string(runes[:prev]) + string(runes[cursor:])
+		if input.value() != want || input.cursor != prev || !utf8.ValidString(input.value()) {
+			t.Logf("backspace at %d in %q left %q at %d, want %q", cursor, before, input.value(), input.cursor, want)
+			return false
+		}
+		if cursor > 0 && !clusterStarts(before)[prev] {
+			t.Logf("backspace at %d in %q split a cluster", cursor, before)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTextInputEditingProperties(t *testing.T) {
+	property := func(text unicodeText, seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		var input textInput
+		input.setValue(string(text))
+		for range 20 {
+			var key tea.KeyMsg
+			if r.Intn(4) == 0 {
+				key = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(textPieces[r.Intn(len(textPieces))])}
+			} else {
+				key = editingKeys[r.Intn(len(editingKeys))]
+			}
+			input.update(key)
+
+			value := input.value()
+			if !utf8.ValidString(value) || !clusterStarts(value)[input.cursor] {
+				t.Logf("%q left the cursor at %d in %q, not between clusters", key, input.cursor, value)
+				return false
+			}
+			_ = input.view()
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}